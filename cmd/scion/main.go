@@ -1,13 +1,99 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 
 	"github.com/ongasatoshi/scion/internal/cmd"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/pkg/output"
 )
 
+// 終了コード
+// 自動化ツールが失敗の種類を判別できるよう、エラーの種類ごとに異なる値を返す
+const (
+	exitError              = 1 // 分類されないエラー
+	exitNotGitRepo         = 2 // Gitリポジトリの外で実行された
+	exitWorktreeExists     = 3 // worktree が既に存在する
+	exitUncommittedChanges = 4 // 未コミットの変更がある
+	exitBranchNotFound     = 5 // ブランチまたは worktree が見つからない
+	exitGitFailed          = 6 // gitコマンドが失敗した
+	exitConflict           = 7 // リベースやマージが衝突した
+)
+
+// errorKinds はエラーの種類と、JSON出力のコード・終了コードの対応
+// gitコマンドの失敗は他の種類のエラーに含まれることがあるため、最後に判定する
+var errorKinds = []struct {
+	kind error
+	code string
+	exit int
+}{
+	{git.ErrNotGitRepo, "not_git_repo", exitNotGitRepo},
+	{git.ErrWorktreeExists, "worktree_exists", exitWorktreeExists},
+	{git.ErrUncommittedChanges, "uncommitted_changes", exitUncommittedChanges},
+	{git.ErrBranchNotFound, "branch_not_found", exitBranchNotFound},
+	{git.ErrConflict, "conflict", exitConflict},
+	{git.ErrGitFailed, "git_failed", exitGitFailed},
+}
+
+// errorOutput は --error-format json で出力するエラーの情報
+type errorOutput struct {
+	Error struct {
+		Code     string        `json:"code"`
+		ExitCode int           `json:"exit_code"`
+		Message  string        `json:"message"`
+		Git      *gitErrorInfo `json:"git,omitempty"`
+	} `json:"error"`
+}
+
+// gitErrorInfo は失敗したgitコマンドの情報
+type gitErrorInfo struct {
+	Args     []string `json:"args"`
+	Stderr   string   `json:"stderr"`
+	ExitCode int      `json:"exit_code"`
+}
+
 func main() {
-	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+	err := cmd.Execute()
+	if err == nil {
+		return
+	}
+	os.Exit(reportError(output.Default(), cmd.ErrorFormat(), err))
+}
+
+// classify はエラーの種類から、JSON出力のコードと終了コードを返す
+// どの種類にも当てはまらない場合は "error" と exitError を返す
+func classify(err error) (string, int) {
+	for _, k := range errorKinds {
+		if errors.Is(err, k.kind) {
+			return k.code, k.exit
+		}
+	}
+	return "error", exitError
+}
+
+// newErrorOutput は --error-format json で出力するエラーの情報を生成する
+// gitコマンドの失敗を含む場合は、そのコマンドの引数・標準エラー出力・終了コードも含める
+func newErrorOutput(err error) errorOutput {
+	var out errorOutput
+	out.Error.Code, out.Error.ExitCode = classify(err)
+	out.Error.Message = err.Error()
+	var cmdErr *git.CommandError
+	if errors.As(err, &cmdErr) {
+		out.Error.Git = &gitErrorInfo{Args: cmdErr.Args, Stderr: cmdErr.Stderr, ExitCode: cmdErr.ExitCode}
+	}
+	return out
+}
+
+// reportError はエラーを format の形式で p の標準エラー出力に出力し、終了コードを返す
+func reportError(p *output.Printer, format string, err error) int {
+	if format == cmd.ErrorFormatJSON {
+		out := newErrorOutput(err)
+		json.NewEncoder(p.Stderr()).Encode(out)
+		return out.Error.ExitCode
 	}
+	p.Error("%v", err)
+	_, exit := classify(err)
+	return exit
 }
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ongasatoshi/scion/internal/cmd"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/pkg/output"
+)
+
+// gitFailure は失敗したgitコマンドのエラーを返す
+func gitFailure() *git.CommandError {
+	return &git.CommandError{
+		Args:     []string{"worktree", "add", "/repo/wtree/feature"},
+		Stderr:   "fatal: '/repo/wtree/feature' already exists",
+		ExitCode: 128,
+		Err:      errors.New("exit status 128"),
+	}
+}
+
+func TestClassify(t *testing.T) {
+	// 終了コードとコードは自動化ツールとの契約のため、値そのものを検証する
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+		wantExit int
+	}{
+		{"not git repo", git.Errorf(git.ErrNotGitRepo, i18n.MsgNotGitRepository), "not_git_repo", 2},
+		{"worktree exists", git.Errorf(git.ErrWorktreeExists, i18n.MsgNotGitRepository), "worktree_exists", 3},
+		{"uncommitted changes", git.Errorf(git.ErrUncommittedChanges, i18n.MsgNotGitRepository), "uncommitted_changes", 4},
+		{"branch not found", git.Errorf(git.ErrBranchNotFound, i18n.MsgNotGitRepository), "branch_not_found", 5},
+		{"git failed", gitFailure(), "git_failed", 6},
+		{"conflict", git.Errorf(git.ErrConflict, i18n.MsgNotGitRepository), "conflict", 7},
+		{"wrapped kind", fmt.Errorf("create: %w", git.Errorf(git.ErrBranchNotFound, i18n.MsgNotGitRepository)), "branch_not_found", 5},
+		// gitコマンドの失敗を原因に持つエラーは、原因ではなくエラー自身の種類で判定する
+		{"kind with git cause", git.Errorf(git.ErrWorktreeExists, i18n.MsgCreateRemoveFailed, gitFailure()), "worktree_exists", 3},
+		{"unclassified", errors.New("something went wrong"), "error", 1},
+		{"translated", i18n.Errorf(i18n.MsgNotGitRepository), "error", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, exit := classify(tt.err)
+			if code != tt.wantCode || exit != tt.wantExit {
+				t.Errorf("classify() = (%q, %d), want (%q, %d)", code, exit, tt.wantCode, tt.wantExit)
+			}
+		})
+	}
+}
+
+func TestErrorKindsAreDistinct(t *testing.T) {
+	codes := map[string]bool{}
+	exits := map[int]bool{exitError: true}
+	for _, k := range errorKinds {
+		if codes[k.code] || exits[k.exit] {
+			t.Errorf("duplicate code %q or exit code %d", k.code, k.exit)
+		}
+		codes[k.code] = true
+		exits[k.exit] = true
+	}
+}
+
+func TestReportErrorJSON(t *testing.T) {
+	var out, errOut bytes.Buffer
+	p := output.NewPrinter(&out, &errOut)
+
+	err := fmt.Errorf("failed to create worktree: %w", gitFailure())
+	if exit := reportError(p, cmd.ErrorFormatJSON, err); exit != exitGitFailed {
+		t.Errorf("expected exit code %d, got %d", exitGitFailed, exit)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected nothing on stdout, got %q", out.String())
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(errOut.Bytes(), &got); err != nil {
+		t.Fatalf("expected JSON on stderr, got %q: %v", errOut.String(), err)
+	}
+	expected := map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":      "git_failed",
+			"exit_code": float64(6),
+			"message":   err.Error(),
+			"git": map[string]interface{}{
+				"args":      []interface{}{"worktree", "add", "/repo/wtree/feature"},
+				"stderr":    "fatal: '/repo/wtree/feature' already exists",
+				"exit_code": float64(128),
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected JSON:\n got: %v\nwant: %v", got, expected)
+	}
+}
+
+func TestReportErrorJSONWithoutGit(t *testing.T) {
+	var errOut bytes.Buffer
+	p := output.NewPrinter(&bytes.Buffer{}, &errOut)
+
+	if exit := reportError(p, cmd.ErrorFormatJSON, errors.New("boom")); exit != exitError {
+		t.Errorf("expected exit code %d, got %d", exitError, exit)
+	}
+	expected := `{"error":{"code":"error","exit_code":1,"message":"boom"}}` + "\n"
+	if errOut.String() != expected {
+		t.Errorf("expected %s, got %s", expected, errOut.String())
+	}
+}
+
+func TestReportErrorText(t *testing.T) {
+	var out, errOut bytes.Buffer
+	p := output.NewPrinter(&out, &errOut)
+
+	err := git.Errorf(git.ErrConflict, i18n.MsgNotGitRepository)
+	if exit := reportError(p, cmd.ErrorFormatText, err); exit != exitConflict {
+		t.Errorf("expected exit code %d, got %d", exitConflict, exit)
+	}
+	if !strings.Contains(errOut.String(), "✗ "+err.Error()) {
+		t.Errorf("expected error message on stderr, got %q", errOut.String())
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected nothing on stdout, got %q", out.String())
+	}
+}
@@ -0,0 +1,181 @@
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// procDir はプロセス情報を参照するディレクトリ
+// /proc がない環境（macOS など）では ps と lsof でプロセスを調べる
+var procDir = "/proc"
+
+// runCommand はコマンドを実行して標準出力を返す
+// テストでは ps と lsof の出力を差し替えられるよう、変数にしている
+var runCommand = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).Output()
+}
+
+// ErrUnsupported は /proc も ps と lsof もなく、実行中のプロセスを調べられないことを表す
+var ErrUnsupported = errors.New("agent detection is not supported on this system")
+
+// Process は実行中のエージェントプロセス
+type Process struct {
+	PID  int
+	Name string
+	// Dir はプロセスのカレントディレクトリ
+	Dir string
+}
+
+// Scan は names のいずれかに一致する実行中のプロセスを返す
+// プロセス名は実行ファイル名、またはインタプリタ経由で実行されたスクリプト名（node claude など）と比較する
+// プロセスを調べる方法がない場合は ErrUnsupported を返す
+func Scan(names []string) ([]Process, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(procDir)
+	if os.IsNotExist(err) {
+		return scanPS(names)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var processes []Process
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		// 権限のないプロセスや終了したプロセスは無視する
+		cmdline, err := os.ReadFile(filepath.Join(procDir, entry.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+		name, ok := matchName(cmdline, names)
+		if !ok {
+			continue
+		}
+		dir, err := os.Readlink(filepath.Join(procDir, entry.Name(), "cwd"))
+		if err != nil {
+			continue
+		}
+
+		processes = append(processes, Process{PID: pid, Name: name, Dir: dir})
+	}
+	return processes, nil
+}
+
+// scanPS は ps でプロセスのコマンドラインを、lsof でカレントディレクトリを調べる
+// ps の出力では引数の区切りと引数に含まれる空白を区別できないため、空白を含むパスで実行されたプロセスは検出できない
+func scanPS(names []string) ([]Process, error) {
+	out, err := runCommand("ps", "-axo", "pid=,command=")
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, ErrUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []string
+	matched := map[int]string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		name, ok := matchName([]byte(strings.Join(fields[1:], "\x00")), names)
+		if !ok {
+			continue
+		}
+		pids = append(pids, fields[0])
+		matched[pid] = name
+	}
+	if len(pids) == 0 {
+		return nil, nil
+	}
+
+	out, err = runCommand("lsof", "-a", "-d", "cwd", "-Fpn", "-p", strings.Join(pids, ","))
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, ErrUnsupported
+	}
+	// lsof は調べられないプロセスがあると終了コード 1 を返すため、その場合も得られた出力を使う
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err
+	}
+	return parseLsof(out, matched), nil
+}
+
+// parseLsof は lsof -Fpn の出力から、matched に含まれるプロセスのカレントディレクトリを読み取る
+// 出力は p<PID> の行に続いて n<パス> の行が並ぶ
+func parseLsof(out []byte, matched map[int]string) []Process {
+	var processes []Process
+	pid := 0
+	for _, line := range strings.Split(string(out), "\n") {
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			pid, _ = strconv.Atoi(line[1:])
+		case 'n':
+			name, ok := matched[pid]
+			if !ok || !filepath.IsAbs(line[1:]) {
+				continue
+			}
+			processes = append(processes, Process{PID: pid, Name: name, Dir: line[1:]})
+		}
+	}
+	return processes
+}
+
+// matchName は NUL 区切りのコマンドラインの先頭2要素のいずれかが names に一致するかどうかを返す
+func matchName(cmdline []byte, names []string) (string, bool) {
+	args := bytes.Split(bytes.TrimRight(cmdline, "\x00"), []byte{0})
+	if len(args) > 2 {
+		args = args[:2]
+	}
+
+	for _, arg := range args {
+		base := filepath.Base(string(arg))
+		for _, name := range names {
+			if base == name {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Group はプロセスをカレントディレクトリを含むディレクトリごとに分類する
+// worktree が入れ子になっている場合は、最も深いディレクトリに分類する
+func Group(processes []Process, dirs []string) map[string][]Process {
+	groups := map[string][]Process{}
+	for _, p := range processes {
+		best := ""
+		for _, dir := range dirs {
+			if p.Dir != dir && !strings.HasPrefix(p.Dir, dir+string(filepath.Separator)) {
+				continue
+			}
+			if len(dir) > len(best) {
+				best = dir
+			}
+		}
+		if best != "" {
+			groups[best] = append(groups[best], p)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,187 @@
+package agent
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchName(t *testing.T) {
+	names := []string{"claude", "aider"}
+
+	tests := []struct {
+		cmdline string
+		want    string
+		wantOK  bool
+	}{
+		{"claude\x00--resume\x00", "claude", true},
+		{"/usr/local/bin/aider\x00", "aider", true},
+		{"node\x00/home/user/.npm/bin/claude\x00", "claude", true},
+		{"vim\x00claude.md\x00", "", false},
+		{"bash\x00-c\x00claude\x00", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := matchName([]byte(tt.cmdline), names)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("matchName(%q) = (%q, %v), want (%q, %v)", tt.cmdline, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestGroup(t *testing.T) {
+	processes := []Process{
+		{PID: 1, Dir: "/repo/app"},
+		{PID: 2, Dir: "/repo/app/wtree/feature/src"},
+		{PID: 3, Dir: "/repo/app-2"},
+		{PID: 4, Dir: "/tmp"},
+	}
+
+	groups := Group(processes, []string{"/repo/app", "/repo/app/wtree/feature"})
+
+	if got := groups["/repo/app"]; len(got) != 1 || got[0].PID != 1 {
+		t.Errorf("unexpected processes for /repo/app: %v", got)
+	}
+	if got := groups["/repo/app/wtree/feature"]; len(got) != 1 || got[0].PID != 2 {
+		t.Errorf("unexpected processes for /repo/app/wtree/feature: %v", got)
+	}
+	if len(groups) != 2 {
+		t.Errorf("expected 2 groups, got %v", groups)
+	}
+}
+
+func TestScanFindsCurrentProcess(t *testing.T) {
+	if _, err := os.Stat(procDir); err != nil {
+		t.Skip("/proc is not available")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("failed to get executable: %v", err)
+	}
+
+	processes, err := Scan([]string{filepath.Base(exe)})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	for _, p := range processes {
+		if p.PID == os.Getpid() {
+			return
+		}
+	}
+	t.Errorf("expected to find the current process (pid %d) in %v", os.Getpid(), processes)
+}
+
+// withoutProc は /proc がない環境を再現し、テストの終了時に元に戻す
+func withoutProc(t *testing.T) {
+	t.Helper()
+	saved := procDir
+	procDir = filepath.Join(t.TempDir(), "proc")
+	t.Cleanup(func() { procDir = saved })
+}
+
+// fakeCommands は ps と lsof の出力を差し替え、テストの終了時に元に戻す
+func fakeCommands(t *testing.T, outputs map[string]string, errs map[string]error) {
+	t.Helper()
+	saved := runCommand
+	runCommand = func(name string, args ...string) ([]byte, error) {
+		return []byte(outputs[name]), errs[name]
+	}
+	t.Cleanup(func() { runCommand = saved })
+}
+
+func TestScanWithPS(t *testing.T) {
+	withoutProc(t)
+	fakeCommands(t, map[string]string{
+		"ps": "    1 /sbin/launchd\n" +
+			"  501 node /usr/local/bin/claude --resume\n" +
+			"  502 vim claude.md\n" +
+			"  503 /opt/homebrew/bin/aider\n",
+		"lsof": "p501\nn/repo/app/wtree/feature\np503\nn/repo/app\n",
+	}, nil)
+
+	processes, err := Scan([]string{"claude", "aider"})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	expected := []Process{
+		{PID: 501, Name: "claude", Dir: "/repo/app/wtree/feature"},
+		{PID: 503, Name: "aider", Dir: "/repo/app"},
+	}
+	if len(processes) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, processes)
+	}
+	for i := range expected {
+		if processes[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], processes[i])
+		}
+	}
+}
+
+func TestScanWithPSUsesPartialLsofOutput(t *testing.T) {
+	withoutProc(t)
+	// 調べられないプロセスがあると lsof は失敗するが、得られたディレクトリは使う
+	fakeCommands(t, map[string]string{
+		"ps":   "  501 claude\n  502 claude\n",
+		"lsof": "p501\nn/repo/app\n",
+	}, map[string]error{"lsof": &exec.ExitError{}})
+
+	processes, err := Scan([]string{"claude"})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(processes) != 1 || processes[0].PID != 501 {
+		t.Errorf("expected only pid 501, got %v", processes)
+	}
+}
+
+func TestScanUnsupported(t *testing.T) {
+	withoutProc(t)
+	notFound := &exec.Error{Name: "ps", Err: exec.ErrNotFound}
+	fakeCommands(t, nil, map[string]error{"ps": notFound})
+
+	if _, err := Scan([]string{"claude"}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported without ps, got %v", err)
+	}
+
+	fakeCommands(t, map[string]string{"ps": "  501 claude\n"}, map[string]error{"lsof": &exec.Error{Name: "lsof", Err: exec.ErrNotFound}})
+	if _, err := Scan([]string{"claude"}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported without lsof, got %v", err)
+	}
+}
+
+func TestScanWithPSFindsCurrentProcess(t *testing.T) {
+	for _, name := range []string{"ps", "lsof"} {
+		if _, err := exec.LookPath(name); err != nil {
+			t.Skipf("%s is not available", name)
+		}
+	}
+	withoutProc(t)
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("failed to get executable: %v", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+
+	processes, err := Scan([]string{filepath.Base(exe)})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	for _, p := range processes {
+		if p.PID == os.Getpid() {
+			if p.Dir != wd {
+				t.Errorf("expected directory %s, got %s", wd, p.Dir)
+			}
+			return
+		}
+	}
+	t.Errorf("expected to find the current process (pid %d) in %v", os.Getpid(), processes)
+}
@@ -0,0 +1,165 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+)
+
+// 記録する操作の種類
+const (
+	ActionCreate      = "create"
+	ActionClear       = "clear"
+	ActionConfigSet   = "config_set"
+	ActionConfigReset = "config_reset"
+	ActionSync        = "sync"
+	ActionLand        = "land"
+)
+
+// 操作の結果
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// fileName は監査ログのファイル名
+const fileName = "audit.jsonl"
+
+// Entry は監査ログに記録する1件の操作
+// 1行に1件のJSONとして保存される
+type Entry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	User   string    `json:"user,omitempty"`
+	// Repo はメインworktreeのパス
+	Repo   string `json:"repo,omitempty"`
+	Branch string `json:"branch,omitempty"`
+	Path   string `json:"path,omitempty"`
+	// Before と After は操作前後の HEAD のコミット
+	Before  string `json:"before,omitempty"`
+	After   string `json:"after,omitempty"`
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+	// Details は操作ごとの追加情報（設定キーなど）
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Commit は操作後、なければ操作前のコミットを返す
+func (e Entry) Commit() string {
+	if e.After != "" {
+		return e.After
+	}
+	return e.Before
+}
+
+// StateDir は scion の状態を保存するディレクトリを返す
+// $XDG_STATE_HOME が設定されていればその下、なければ ~/.local/state/scion
+func StateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "scion"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "scion"), nil
+}
+
+// DefaultPath は監査ログのパスを返す
+func DefaultPath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// CurrentUser は操作したユーザー名を返す
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// Append は監査ログの末尾に1件追記する
+// ログには作業内容のパスが含まれるため、本人のみ読み書きできる権限で作成する
+func Append(path string, e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	// 1回の書き込みにまとめ、並行して実行された scion の記録が混ざらないようにする
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Filter は監査ログから読み込む操作の条件
+// 空のフィールドは条件として扱わない
+type Filter struct {
+	Repo   string
+	Branch string
+	Action string
+	// Limit は新しい順に返す最大件数（0 の場合は無制限）
+	Limit int
+}
+
+// match は操作が条件に一致するかどうかを返す
+func (f Filter) match(e Entry) bool {
+	return (f.Repo == "" || e.Repo == f.Repo) &&
+		(f.Branch == "" || e.Branch == f.Branch) &&
+		(f.Action == "" || e.Action == f.Action)
+}
+
+// Read は監査ログから条件に一致する操作を新しい順に返す
+// ログが存在しない場合は空を返し、解析できない行は読み飛ばす
+func Read(path string, filter Filter) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if filter.match(e) {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// 新しい順に並べ替える
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[:filter.Limit]
+	}
+	return entries, nil
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "audit.jsonl")
+
+	entries := []Entry{
+		{Action: ActionCreate, Repo: "/repo", Branch: "feature/a", After: "aaa", Outcome: OutcomeSuccess},
+		{Action: ActionCreate, Repo: "/other", Branch: "feature/a", Outcome: OutcomeFailure, Error: "boom"},
+		{Action: ActionClear, Repo: "/repo", Branch: "feature/a", Before: "bbb", Outcome: OutcomeSuccess},
+		{Action: ActionClear, Repo: "/repo", Branch: "feature/b", Outcome: OutcomeSuccess},
+	}
+	for i, e := range entries {
+		e.Time = time.Date(2026, 1, 1, 0, 0, i, 0, time.UTC)
+		if err := Append(path, e); err != nil {
+			t.Fatalf("failed to append: %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat log: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("unexpected permission: %v", perm)
+	}
+
+	got, err := Read(path, Filter{Repo: "/repo", Branch: "feature/a"})
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+	if len(got) != 2 || got[0].Action != ActionClear || got[1].Action != ActionCreate {
+		t.Fatalf("unexpected entries: %+v", got)
+	}
+	if got[0].Commit() != "bbb" || got[1].Commit() != "aaa" {
+		t.Errorf("unexpected commits: %q %q", got[0].Commit(), got[1].Commit())
+	}
+
+	got, err = Read(path, Filter{Limit: 1})
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+	if len(got) != 1 || got[0].Branch != "feature/b" {
+		t.Errorf("unexpected entries: %+v", got)
+	}
+}
+
+func TestReadSkipsInvalidLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	data := "not json\n" + `{"action":"clear","outcome":"success"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	got, err := Read(path, Filter{})
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+	if len(got) != 1 || got[0].Action != ActionClear {
+		t.Errorf("unexpected entries: %+v", got)
+	}
+
+	// ログが存在しない場合は空
+	got, err = Read(filepath.Join(t.TempDir(), "missing.jsonl"), Filter{})
+	if err != nil || len(got) != 0 {
+		t.Errorf("expected no entries, got %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/ongasatoshi/scion/internal/audit"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// recordAudit は変更を伴う操作の結果を監査ログに記録する
+// 記録に失敗しても操作自体は失敗させず、警告を表示する
+func recordAudit(p *printer, e audit.Entry, err error) {
+	path, pathErr := audit.DefaultPath()
+	if pathErr != nil {
+		p.Warning(i18n.MsgAuditWriteFailed, pathErr)
+		return
+	}
+
+	e.Time = time.Now()
+	e.User = audit.CurrentUser()
+	// Gitリポジトリの外で実行された設定の変更はリポジトリを記録しない
+	if root, rootErr := git.GetMainWorktreeRoot(); rootErr == nil {
+		e.Repo = root
+	}
+	e.Outcome = audit.OutcomeSuccess
+	if err != nil {
+		e.Outcome = audit.OutcomeFailure
+		e.Error = err.Error()
+	}
+
+	if err := audit.Append(path, e); err != nil {
+		p.Warning(i18n.MsgAuditWriteFailed, err)
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/picker"
+	"github.com/spf13/cobra"
+)
+
+var cdCmd = &cobra.Command{
+	Use:               "cd [branch-name]",
+	Short:             i18n.CmdCdShort,
+	Long:              i18n.CmdCdLong,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeBranches(true),
+	RunE:              runCd,
+}
+
+func init() {
+	rootCmd.AddCommand(cdCmd)
+}
+
+func runCd(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
+	// Gitリポジトリかどうか確認
+	if !git.IsGitRepository() {
+		return git.Errorf(git.ErrNotGitRepo, i18n.MsgNotGitRepository)
+	}
+
+	wt, err := worktreeArg(args)
+	if errors.Is(err, picker.ErrCancelled) {
+		// シェルの cd に渡されるため、標準出力には何も出力しない
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(p.Stdout(), wt.Path)
+	return nil
+}
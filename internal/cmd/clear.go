@@ -1,14 +1,14 @@
 package cmd
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
 
+	"github.com/ongasatoshi/scion/internal/audit"
 	"github.com/ongasatoshi/scion/internal/git"
-	"github.com/ongasatoshi/scion/pkg/output"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/layout"
+	"github.com/ongasatoshi/scion/internal/picker"
 	"github.com/spf13/cobra"
 )
 
@@ -19,51 +19,88 @@ var (
 )
 
 var clearCmd = &cobra.Command{
-	Use:   "clear <branch-name>",
-	Short: "既存のworktreeブランチを削除",
-	Long: `clear コマンドは既存のGit Worktreeブランチとその関連ディレクトリを削除します。
-
-例:
-  scion clear feature/old-feature
-  scion clear feature/experimental --force
-  scion clear feature/temp --keep-branch
-  scion clear --all`,
+	Use:   "clear [branch-name]",
+	Short: i18n.CmdClearShort,
+	Long:  i18n.CmdClearLong,
 	Args: func(cmd *cobra.Command, args []string) error {
 		all, _ := cmd.Flags().GetBool("all")
 		if all {
 			return nil
 		}
-		if len(args) != 1 {
-			return fmt.Errorf("ブランチ名を指定してください (または --all フラグを使用)")
+		// 端末ではブランチ名を省略するとピッカーで選択できる
+		if len(args) == 0 && !picker.Available() {
+			return i18n.Errorf(i18n.MsgClearBranchRequired)
 		}
-		return nil
+		return cobra.MaximumNArgs(1)(cmd, args)
 	},
-	RunE: runClear,
+	ValidArgsFunction: completeWorktreeBranches(false),
+	RunE:              runClear,
 }
 
 func init() {
 	rootCmd.AddCommand(clearCmd)
 
-	clearCmd.Flags().BoolVarP(&clearForce, "force", "f", false, "未コミットの変更があっても強制的に削除")
-	clearCmd.Flags().BoolVarP(&clearAll, "all", "a", false, "すべてのworktreeを削除")
-	clearCmd.Flags().BoolVar(&clearKeepBranch, "keep-branch", false, "worktreeは削除するがブランチは保持")
+	clearCmd.Flags().BoolVarP(&clearForce, "force", "f", false, i18n.FlagClearForce)
+	clearCmd.Flags().BoolVarP(&clearAll, "all", "a", false, i18n.FlagClearAll)
+	clearCmd.Flags().BoolVar(&clearKeepBranch, "keep-branch", false, i18n.FlagClearKeepBranch)
 }
 
 func runClear(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
 	// Gitリポジトリかどうか確認
 	if !git.IsGitRepository() {
-		return fmt.Errorf("Gitリポジトリ内で実行してください")
+		return git.Errorf(git.ErrNotGitRepo, i18n.MsgNotGitRepository)
 	}
 
 	if clearAll {
-		return runClearAll()
+		return runClearAll(p)
+	}
+
+	if len(args) == 0 {
+		return runClearPicked(p)
 	}
 
+	// 存在しない worktree は確認せずにエラーにする
 	branchName := args[0]
-	return clearWorktree(branchName)
+	target, err := findClearTarget(branchName)
+	if err != nil {
+		return err
+	}
+	if !clearForce {
+		ok, err := confirmDestructive(p, i18n.T(i18n.MsgClearConfirm, branchName))
+		if err != nil || !ok {
+			return err
+		}
+	}
+	return clearWorktreeByPath(p, target.path, target.name, target.isBranch, clearForce)
 }
 
-func runClearAll() error {
+// runClearPicked はピッカーで選択した worktree を削除する
+func runClearPicked(p *printer) error {
+	selected, err := pickWorktrees(true, false)
+	if errors.Is(err, picker.ErrCancelled) {
+		p.Info(i18n.MsgCancelled)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !clearForce {
+		ok, err := confirmDestructive(p, i18n.T(i18n.MsgClearConfirmSelected, len(selected)))
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	if failed := clearWorktrees(p, selected); failed > 0 {
+		return i18n.Errorf(i18n.MsgClearSomeFailed)
+	}
+	return nil
+}
+
+func runClearAll(p *printer) error {
 	worktrees, err := git.ListWorktrees()
 	if err != nil {
 		return err
@@ -79,90 +116,166 @@ func runClearAll() error {
 	}
 
 	if len(toRemove) == 0 {
-		output.Info("削除するworktreeがありません")
+		p.Info(i18n.MsgClearNothingToRemove)
 		return nil
 	}
 
 	// 削除対象を表示
-	output.Info("削除対象のworktree:")
+	store, _ := openWorktreeStore()
+	p.Info(i18n.MsgClearTargets)
 	for _, wt := range toRemove {
-		fmt.Printf("  - %s (%s)\n", wt.Branch, wt.Path)
+		name, _ := worktreeName(wt, store)
+		fmt.Fprintf(p.Stdout(), "  - %s (%s)\n", name, wt.Path)
 	}
 
 	// 確認プロンプト（--force でない場合）
-	config := GetConfig()
-	if config.UI.ConfirmDestructive && !clearForce {
-		fmt.Print("\nすべてのworktreeを削除しますか? [y/N]: ")
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(strings.ToLower(response))
-
-		if response != "y" && response != "yes" {
-			output.Info("キャンセルしました")
-			return nil
+	if !clearForce {
+		ok, err := confirmDestructive(p, "\n"+i18n.T(i18n.MsgClearConfirmAll))
+		if err != nil || !ok {
+			return err
 		}
 	}
 
-	// 削除を実行
-	for _, wt := range toRemove {
-		if err := clearWorktreeByPath(wt.Path, wt.Branch); err != nil {
-			output.Error("'%s' の削除に失敗しました: %v", wt.Branch, err)
-			continue
-		}
-		output.Success("worktreeを削除しました: %s", wt.Branch)
+	// 削除を実行（すべて削除できた場合のみ完了を表示する）
+	if failed := clearWorktrees(p, toRemove); failed > 0 {
+		return i18n.Errorf(i18n.MsgClearSomeFailed)
 	}
 
-	output.Success("すべてのworktreeを削除しました")
+	p.Success(i18n.MsgClearRemovedAll)
 	return nil
 }
 
-func clearWorktree(branchName string) error {
-	// リポジトリルートを取得
-	repoRoot, err := git.GetRepositoryRoot()
-	if err != nil {
-		return err
+// clearWorktrees は複数の worktree を順に削除し、進捗を worktree ごとに表示する
+// 削除に失敗した worktree の数を返す
+func clearWorktrees(p *printer, worktrees []git.WorktreeInfo) int {
+	store, _ := openWorktreeStore()
+	labels := make([]string, 0, len(worktrees))
+	for _, wt := range worktrees {
+		name, _ := worktreeName(wt, store)
+		labels = append(labels, name)
 	}
 
-	config := GetConfig()
-	baseDir := config.Worktree.BaseDir
+	failed := 0
+	progress := p.StartProgressList(labels)
+	for i, wt := range worktrees {
+		progress.Start(i)
+		if err := clearListedWorktree(&printer{progress.Printer()}, wt, store, clearForce); err != nil {
+			progress.Fail(i, err)
+			failed++
+			continue
+		}
+		progress.Done(i)
+	}
+	progress.Stop()
+	return failed
+}
+
+// clearTarget は削除する worktree のパスと、ブランチとして扱う名前
+type clearTarget struct {
+	path     string
+	name     string
+	isBranch bool
+}
 
-	// worktreeパスを構築
-	parentDir := filepath.Dir(repoRoot)
-	safeBranchName := strings.ReplaceAll(branchName, "/", "-")
-	worktreePath := filepath.Join(parentDir, baseDir, safeBranchName)
+// findClearTarget は名前（ブランチ名、または detached HEAD の worktree の名前）で指定した worktree を探す
+// ブランチをチェックアウトしている worktree、detached HEAD の worktree、テンプレートから求めたパスの順に探す
+func findClearTarget(name string) (clearTarget, error) {
+	if wt, err := findWorktree(name); err == nil {
+		store, _ := openWorktreeStore()
+		wtName, isBranch := worktreeName(wt, store)
+		return clearTarget{path: wt.Path, name: wtName, isBranch: isBranch}, nil
+	}
 
-	return clearWorktreeByPath(worktreePath, branchName)
+	config, err := GetConfig().ForBranch(name)
+	if err != nil {
+		return clearTarget{}, err
+	}
+	worktreePath, err := worktreePathFor(config, name)
+	if err != nil {
+		return clearTarget{}, err
+	}
+	if !git.WorktreeExists(worktreePath) {
+		return clearTarget{}, git.Errorf(git.ErrBranchNotFound, i18n.MsgClearWorktreeNotFound, worktreePath)
+	}
+	return clearTarget{path: worktreePath, name: name, isBranch: true}, nil
 }
 
-func clearWorktreeByPath(worktreePath, branchName string) error {
+// clearListedWorktree は git worktree list で取得した worktree を削除する
+// ブランチを持たない detached HEAD の worktree では、ブランチを削除しない
+func clearListedWorktree(p *printer, wt git.WorktreeInfo, store *layout.Store, force bool) error {
+	name, isBranch := worktreeName(wt, store)
+	return clearWorktreeByPath(p, wt.Path, name, isBranch, force)
+}
+
+// clearWorktreeByPath は worktree を削除し、isBranch が true の場合は name のブランチも削除する
+// force が true の場合は、未コミットの変更やフックの失敗があっても削除し、マージされていないブランチも削除する
+// 削除を試みた結果は、削除前にチェックアウトしていたコミットとともに監査ログに記録する
+func clearWorktreeByPath(p *printer, worktreePath, name string, isBranch, force bool) (err error) {
 	// worktreeが存在するか確認
 	if !git.WorktreeExists(worktreePath) {
-		return fmt.Errorf("worktree '%s' が見つかりません", worktreePath)
+		return git.Errorf(git.ErrBranchNotFound, i18n.MsgClearWorktreeNotFound, worktreePath)
 	}
 
-	// 未コミットの変更を確認
-	if !clearForce {
+	entry := audit.Entry{Action: audit.ActionClear, Branch: name, Path: worktreePath, Details: map[string]string{}}
+	entry.Before, _ = git.HeadCommit(worktreePath)
+	if force {
+		entry.Details["force"] = "true"
+	}
+	if !isBranch {
+		entry.Details["detached"] = "true"
+	}
+	defer func() { recordAudit(p, entry, err) }()
+
+	// ルールはブランチ名で選択するため、detached HEAD の worktree には適用しない
+	branchName := ""
+	if isBranch {
+		branchName = name
+	}
+
+	// 未コミットの変更を確認（確認できない場合は変更を失わないよう中止する）
+	clean := false
+	if !force {
 		hasChanges, err := git.HasUncommittedChanges(worktreePath)
 		if err != nil {
-			output.Warning("ステータスの確認に失敗しました: %v", err)
-		} else if hasChanges {
-			return fmt.Errorf("worktree '%s' には未コミットの変更があります\n--force オプションで強制削除できます", branchName)
+			return i18n.Errorf(i18n.MsgForceHint, i18n.Errorf(i18n.MsgClearStatusCheckFailed, err))
+		}
+		if hasChanges {
+			return git.Errorf(git.ErrUncommittedChanges, i18n.MsgClearUncommittedChanges, name)
+		}
+		clean = true
+	}
+
+	// pre_clear フックを実行（ブランチ名に一致するルールのフックを含む）
+	config := GetConfig()
+	if isBranch {
+		if config, err = config.ForBranch(branchName); err != nil {
+			return err
+		}
+	}
+	if err := runHooks(p, "pre_clear", config.Hooks.PreClear, worktreePath, branchName); err != nil {
+		if !force {
+			return i18n.Errorf(i18n.MsgForceHint, err)
 		}
+		p.Warning("%v", err)
 	}
 
 	// worktreeを削除
-	output.Info("worktreeを削除しています: %s", branchName)
-	if err := git.RemoveWorktree(worktreePath, clearForce); err != nil {
+	// git は初期化済みのサブモジュールを含む worktree を --force なしでは削除しないため、未コミットの変更がないことを確認できた場合のみ強制的に削除する
+	// サブモジュールのリポジトリは worktree の管理ディレクトリ（.git/worktrees/<name>/modules）にあり、一緒に削除される
+	p.Info(i18n.MsgClearRemoving, name)
+	if err := git.RemoveWorktree(worktreePath, force || (clean && git.HasInitializedSubmodules(worktreePath))); err != nil {
 		return err
 	}
-	output.Success("Worktreeを削除しました: %s", worktreePath)
+	p.Success(i18n.MsgClearRemovedWorktree, worktreePath)
+	updateWorktreeStore(p, func(store *layout.Store) { store.Remove(worktreePath) })
 
 	// ブランチも削除（--keep-branch でない場合）
-	if !clearKeepBranch {
-		if err := git.DeleteBranch(branchName, clearForce); err != nil {
-			output.Warning("ブランチの削除に失敗しました: %v", err)
+	if isBranch && !clearKeepBranch {
+		if err := git.DeleteBranch(branchName, force); err != nil {
+			p.Warning(i18n.MsgClearBranchDeleteFailed, err)
 		} else {
-			output.Success("ブランチ '%s' を削除しました", branchName)
+			entry.Details["branch_deleted"] = "true"
+			p.Success(i18n.MsgClearDeletedBranch, branchName)
 		}
 	}
 
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+func TestClearWorktreeByPath(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	resetCreateFlags(t)
+	if err := createWorktree(discardPrinter(), "feature/x"); err != nil {
+		t.Fatalf("failed to create feature/x: %v", err)
+	}
+	path := worktreePathForBranch(t, "feature/x")
+
+	// 未コミットの変更がある場合は削除しない
+	if err := os.WriteFile(filepath.Join(path, "test.txt"), []byte("dirty"), 0644); err != nil {
+		t.Fatalf("failed to modify worktree: %v", err)
+	}
+	if err := clearWorktreeByPath(discardPrinter(), path, "feature/x", true, false); !errors.Is(err, git.ErrUncommittedChanges) {
+		t.Errorf("expected ErrUncommittedChanges, got %v", err)
+	}
+	runGit(t, path, "checkout", "--", "test.txt")
+
+	// 未コミットの変更を確認できない場合も、変更を失わないよう削除しない
+	index := runGit(t, path, "rev-parse", "--path-format=absolute", "--git-path", "index")
+	original, err := os.ReadFile(index)
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	if err := os.WriteFile(index, []byte("broken"), 0644); err != nil {
+		t.Fatalf("failed to corrupt index: %v", err)
+	}
+	if err := clearWorktreeByPath(discardPrinter(), path, "feature/x", true, false); err == nil {
+		t.Error("expected error when the status check fails")
+	}
+	if !git.WorktreeExists(path) {
+		t.Fatal("expected worktree to be kept when the status check fails")
+	}
+	if err := os.WriteFile(index, original, 0644); err != nil {
+		t.Fatalf("failed to restore index: %v", err)
+	}
+
+	if err := clearWorktreeByPath(discardPrinter(), path, "feature/x", true, false); err != nil {
+		t.Fatalf("clearWorktreeByPath returned error: %v", err)
+	}
+	if git.WorktreeExists(path) || git.BranchExists("feature/x") {
+		t.Error("expected worktree and branch to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "test.txt")); err != nil {
+		t.Errorf("expected main worktree to be untouched: %v", err)
+	}
+}
+
+func TestClearAllReportsFailures(t *testing.T) {
+	setupTestRepo(t)
+	resetCreateFlags(t)
+	t.Cleanup(func() { clearAll = false })
+	cfg.UI.ConfirmDestructive = false
+
+	for _, branch := range []string{"feature/clean", "feature/dirty"} {
+		if err := createWorktree(discardPrinter(), branch); err != nil {
+			t.Fatalf("failed to create %s: %v", branch, err)
+		}
+	}
+	cleanPath := worktreePathForBranch(t, "feature/clean")
+	dirtyPath := worktreePathForBranch(t, "feature/dirty")
+	if err := os.WriteFile(filepath.Join(dirtyPath, "test.txt"), []byte("dirty"), 0644); err != nil {
+		t.Fatalf("failed to modify worktree: %v", err)
+	}
+
+	// --force なしでは未コミットの変更がある worktree を削除できず、エラーを返す
+	clearAll = true
+	out, err := runCommandForTest(runClear)
+	if err == nil {
+		t.Fatal("expected error when a worktree could not be removed")
+	}
+	if strings.Contains(out, i18n.Text(i18n.MsgClearRemovedAll)) {
+		t.Errorf("expected no success message, got %q", out)
+	}
+	if git.WorktreeExists(cleanPath) {
+		t.Error("expected clean worktree to be removed")
+	}
+	if !git.WorktreeExists(dirtyPath) {
+		t.Error("expected dirty worktree to be kept")
+	}
+}
+
+func TestClearUnknownWorktreeBeforeConfirm(t *testing.T) {
+	setupTestRepo(t)
+
+	// 確認を求める前に worktree を探すため、端末でなくても見つからないエラーになる
+	_, err := runCommandForTest(runClear, "feature/typo")
+	if !errors.Is(err, git.ErrBranchNotFound) {
+		t.Errorf("expected ErrBranchNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/ongasatoshi/scion/internal/config"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// completionFunc は引数・フラグの補完候補を返す関数
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completeWorktreeBranches は worktree のブランチ名を補完する
+// includeMain が false の場合はメインworktreeのブランチを候補から除外する
+func completeWorktreeBranches(includeMain bool) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		worktrees, err := git.ListWorktrees()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		// detached HEAD の worktree は clear などで指定できる名前を補完する
+		store, _ := openWorktreeStore()
+		var candidates []string
+		for i, wt := range worktrees {
+			if wt.IsBare || (i == 0 && !includeMain) {
+				continue
+			}
+			if name, _ := worktreeName(wt, store); strings.HasPrefix(name, toComplete) {
+				candidates = append(candidates, name+"\t"+wt.Path)
+			}
+		}
+		return candidates, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeBranches はローカルブランチとリモート追跡ブランチの名前を補完する
+func completeBranches(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	branches, err := git.ListBranches(true)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterPrefix(branches, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRemoteBranches はリモート追跡ブランチの名前（origin/main など）を補完する
+func completeRemoteBranches(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	all, err := git.ListBranches(true)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	local, err := git.ListBranches(false)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	isLocal := make(map[string]bool, len(local))
+	for _, branch := range local {
+		isLocal[branch] = true
+	}
+	var remote []string
+	for _, branch := range all {
+		if !isLocal[branch] {
+			remote = append(remote, branch)
+		}
+	}
+	return filterPrefix(remote, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRevisions はブランチとタグの名前を補完する
+func completeRevisions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	branches, err := git.ListBranches(true)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	tags, err := git.ListTags()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterPrefix(append(tags, branches...), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRemotes はリモートの名前を補完する
+func completeRemotes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	remotes, err := git.ListRemotes()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterPrefix(remotes, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProfiles は設定ファイルに定義されたプロファイル名を補完する
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// 補完時は PersistentPreRunE が実行されないため、ここで設定を読み込む
+	cfg, err := config.Load(cfgFile, "")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterPrefix(cfg.ProfileNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigKey は config get の設定キーを補完する
+func completeConfigKey(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var candidates []string
+	for _, key := range config.Keys() {
+		if !strings.HasPrefix(key.Name, toComplete) {
+			continue
+		}
+		candidates = append(candidates, key.Name+"\t"+key.Description)
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// configValueCompletions はリポジトリの状態から値を補完する設定キー
+var configValueCompletions = map[string]completionFunc{
+	"git.default_remote":      completeRemotes,
+	"git.default_base_branch": completeBranches,
+}
+
+// completeConfigSet は config set の設定キーと値を補完する
+// 値はリモート名・ブランチ名を指定するキーではリポジトリから、それ以外は列挙値、真偽値、デフォルト値を候補とする
+func completeConfigSet(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeConfigKey(cmd, args, toComplete)
+	case 1:
+		if complete, ok := configValueCompletions[args[0]]; ok && git.IsGitRepository() {
+			return complete(cmd, args, toComplete)
+		}
+		for _, key := range config.Keys() {
+			if key.Name == args[0] {
+				return filterPrefix(key.ValueHints(), toComplete), cobra.ShellCompDirectiveNoFileComp
+			}
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// filterPrefix は toComplete で始まる候補を返す
+func filterPrefix(candidates []string, toComplete string) []string {
+	var result []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, toComplete) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
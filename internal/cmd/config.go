@@ -1,111 +1,127 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
-	"strconv"
-	"strings"
+	"sort"
 
+	"github.com/ongasatoshi/scion/internal/audit"
 	"github.com/ongasatoshi/scion/internal/config"
-	"github.com/ongasatoshi/scion/pkg/output"
+	"github.com/ongasatoshi/scion/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
 var (
-	configGlobal bool
-	configLocal  bool
+	configGlobal       bool
+	configLocal        bool
+	configSchemaOutput string
+	configMigrateDry   bool
 )
 
 var configCmd = &cobra.Command{
 	Use:   "config",
-	Short: "scionの設定を管理",
-	Long: `config コマンドはscionの設定を管理します。
-
-サブコマンド:
-  get <key>      - 特定の設定値を取得
-  set <key> <value> - 設定値を更新
-  list           - すべての設定を表示
-  reset          - 設定をデフォルトに戻す
-  edit           - エディタで設定ファイルを開く
-
-例:
-  scion config get worktree.base_dir
-  scion config set ui.color_output false
-  scion config list
-  scion config edit`,
+	Short: i18n.CmdConfigShort,
+	Long:  i18n.CmdConfigLong,
 }
 
 var configGetCmd = &cobra.Command{
-	Use:   "get <key>",
-	Short: "特定の設定値を取得",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runConfigGet,
+	Use:               "get <key>",
+	Short:             i18n.CmdConfigGetShort,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigKey,
+	RunE:              runConfigGet,
 }
 
 var configSetCmd = &cobra.Command{
-	Use:   "set <key> <value>",
-	Short: "設定値を更新",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runConfigSet,
+	Use:               "set <key> <value>",
+	Short:             i18n.CmdConfigSetShort,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeConfigSet,
+	RunE:              runConfigSet,
 }
 
 var configListCmd = &cobra.Command{
 	Use:   "list",
-	Short: "すべての設定を表示",
+	Short: i18n.CmdConfigListShort,
 	Args:  cobra.NoArgs,
 	RunE:  runConfigList,
 }
 
 var configResetCmd = &cobra.Command{
 	Use:   "reset",
-	Short: "設定をデフォルトに戻す",
+	Short: i18n.CmdConfigResetShort,
 	Args:  cobra.NoArgs,
 	RunE:  runConfigReset,
 }
 
 var configEditCmd = &cobra.Command{
 	Use:   "edit",
-	Short: "エディタで設定ファイルを開く",
+	Short: i18n.CmdConfigEditShort,
 	Args:  cobra.NoArgs,
 	RunE:  runConfigEdit,
 }
 
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: i18n.CmdConfigSchemaShort,
+	Long:  i18n.CmdConfigSchemaLong,
+	Args:  cobra.NoArgs,
+	RunE:  runConfigSchema,
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: i18n.CmdConfigMigrateShort,
+	Long:  i18n.CmdConfigMigrateLong,
+	Args:  cobra.NoArgs,
+	RunE:  runConfigMigrate,
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 
-	configCmd.PersistentFlags().BoolVar(&configGlobal, "global", false, "グローバル設定を対象とする")
-	configCmd.PersistentFlags().BoolVar(&configLocal, "local", false, "ローカル設定を対象とする")
+	configCmd.PersistentFlags().BoolVar(&configGlobal, "global", false, i18n.FlagConfigGlobal)
+	configCmd.PersistentFlags().BoolVar(&configLocal, "local", false, i18n.FlagConfigLocal)
 
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configResetCmd)
 	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	configCmd.AddCommand(configMigrateCmd)
+
+	configMigrateCmd.Flags().BoolVar(&configMigrateDry, "dry-run", false, i18n.FlagConfigMigrateDry)
+	configSchemaCmd.Flags().StringVarP(&configSchemaOutput, "output", "o", "", i18n.FlagConfigSchemaOutput)
 }
 
 func runConfigGet(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
 	key := args[0]
 	cfg := GetConfig()
 
-	value, err := getConfigValue(cfg, key)
+	value, err := config.GetValue(cfg, key)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(value)
+	fmt.Fprintln(p.Stdout(), value)
 	return nil
 }
 
-func runConfigSet(cmd *cobra.Command, args []string) error {
+func runConfigSet(cmd *cobra.Command, args []string) (err error) {
+	p := printerFor(cmd)
+
 	key := args[0]
 	value := args[1]
 
 	// 設定ファイルのパスを決定
 	var configPath string
-	var err error
 
 	if configLocal {
 		configPath = config.LocalConfigPath()
@@ -116,14 +132,18 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	entry := audit.Entry{Action: audit.ActionConfigSet, Path: configPath, Details: map[string]string{"key": key, "value": value}}
+	defer func() { recordAudit(p, entry, err) }()
+
 	// 現在の設定を読み込む
-	cfg, err := config.Load("")
+	// プロファイルの値が保存されないよう、プロファイルを適用せずに読み込む
+	cfg, err := config.Load("", "")
 	if err != nil {
 		return err
 	}
 
 	// 設定値を更新
-	if err := setConfigValue(cfg, key, value); err != nil {
+	if err := config.SetValue(cfg, key, value); err != nil {
 		return err
 	}
 
@@ -132,44 +152,45 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	output.Success("設定を更新しました: %s = %s", key, value)
+	p.Success(i18n.MsgConfigUpdated, key, value)
 	return nil
 }
 
 func runConfigList(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
 	cfg := GetConfig()
 
+	if cfg.ActiveProfile != "" {
+		fmt.Fprintln(p.Stdout(), i18n.T(i18n.MsgConfigActiveProfile, cfg.ActiveProfile))
+		fmt.Fprintln(p.Stdout())
+	}
+
 	// グローバル設定を表示
 	globalPath, err := config.GlobalConfigPath()
 	if err == nil {
-		fmt.Printf("グローバル設定 (%s):\n", globalPath)
-		printConfigSection(cfg, "  ")
+		fmt.Fprintln(p.Stdout(), i18n.T(i18n.MsgConfigGlobalHeader, globalPath))
+		printConfigSection(p.Stdout(), cfg, "  ")
 	}
 
 	// ローカル設定の存在を確認して表示
 	localPath := config.LocalConfigPath()
 	if _, err := os.Stat(localPath); err == nil {
-		fmt.Printf("\nローカル設定 (%s):\n", localPath)
-		fmt.Println("  (ローカル設定が存在します)")
+		fmt.Fprintln(p.Stdout())
+		fmt.Fprintln(p.Stdout(), i18n.T(i18n.MsgConfigLocalHeader, localPath))
+		fmt.Fprintln(p.Stdout(), "  "+i18n.Text(i18n.MsgConfigLocalExists))
 	}
 
 	return nil
 }
 
-func runConfigReset(cmd *cobra.Command, args []string) error {
-	cfg := GetConfig()
+func runConfigReset(cmd *cobra.Command, args []string) (err error) {
+	p := printerFor(cmd)
 
 	// 確認プロンプト
-	if cfg.UI.ConfirmDestructive {
-		fmt.Print("設定をデフォルトに戻しますか? [y/N]: ")
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(strings.ToLower(response))
-
-		if response != "y" && response != "yes" {
-			output.Info("キャンセルしました")
-			return nil
-		}
+	ok, err := confirmDestructive(p, i18n.T(i18n.MsgConfigConfirmReset))
+	if err != nil || !ok {
+		return err
 	}
 
 	// デフォルト設定を取得
@@ -177,7 +198,6 @@ func runConfigReset(cmd *cobra.Command, args []string) error {
 
 	// 設定ファイルのパスを決定
 	var configPath string
-	var err error
 
 	if configLocal {
 		configPath = config.LocalConfigPath()
@@ -188,22 +208,20 @@ func runConfigReset(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	defer func() { recordAudit(p, audit.Entry{Action: audit.ActionConfigReset, Path: configPath}, err) }()
+
 	// デフォルト設定を保存
 	if err := config.Save(defaultCfg, configPath); err != nil {
 		return err
 	}
 
-	output.Success("設定をデフォルトにリセットしました")
+	p.Success(i18n.MsgConfigResetDone)
 	return nil
 }
 
 func runConfigEdit(cmd *cobra.Command, args []string) error {
 	// エディタを決定
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		cfg := GetConfig()
-		editor = cfg.Editor.Command
-	}
+	editor := editorCommand()
 
 	// 設定ファイルのパスを決定
 	var configPath string
@@ -232,124 +250,140 @@ func runConfigEdit(cmd *cobra.Command, args []string) error {
 	return execCmd.Run()
 }
 
-func getConfigValue(cfg *config.Config, key string) (string, error) {
-	parts := strings.Split(key, ".")
-	if len(parts) != 2 {
-		return "", fmt.Errorf("無効なキー形式です: %s (例: worktree.base_dir)", key)
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
+	// 対象の設定ファイルを決定
+	var paths []string
+	if cfgFile != "" {
+		paths = append(paths, cfgFile)
+	}
+	if !configLocal || configGlobal {
+		if globalPath, err := config.GlobalConfigPath(); err == nil {
+			paths = append(paths, globalPath)
+		}
+	}
+	if !configGlobal || configLocal {
+		paths = append(paths, config.LocalConfigPath())
 	}
 
-	section := parts[0]
-	field := parts[1]
+	found := false
+	for _, path := range paths {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		found = true
 
-	v := reflect.ValueOf(cfg).Elem()
+		result, err := config.MigrateFile(path, configMigrateDry)
+		if err != nil {
+			return i18n.Errorf(i18n.MsgConfigMigrateFailed, path, err)
+		}
 
-	// セクションを見つける
-	sectionField := v.FieldByNameFunc(func(name string) bool {
-		return strings.EqualFold(name, section)
-	})
-	if !sectionField.IsValid() {
-		return "", fmt.Errorf("セクション '%s' が見つかりません", section)
-	}
+		if !result.Migrated() {
+			p.Info(i18n.MsgConfigUpToDate, path, result.ToVersion)
+			continue
+		}
 
-	// フィールドを見つける
-	fieldValue := sectionField.FieldByNameFunc(func(name string) bool {
-		return strings.EqualFold(name, field) || strings.EqualFold(toSnakeCase(name), field)
-	})
-	if !fieldValue.IsValid() {
-		return "", fmt.Errorf("フィールド '%s' が見つかりません", field)
+		for _, applied := range result.Applied {
+			p.Print("  - %s", i18n.Text(i18n.MessageID(applied)))
+		}
+		if configMigrateDry {
+			p.Info(i18n.MsgConfigWillMigrate, path, result.FromVersion, result.ToVersion)
+		} else {
+			p.Success(i18n.MsgConfigMigrated, path, result.FromVersion, result.ToVersion, path)
+		}
 	}
 
-	return fmt.Sprintf("%v", fieldValue.Interface()), nil
-}
-
-func setConfigValue(cfg *config.Config, key, value string) error {
-	parts := strings.Split(key, ".")
-	if len(parts) != 2 {
-		return fmt.Errorf("無効なキー形式です: %s (例: worktree.base_dir)", key)
+	if !found {
+		p.Info(i18n.MsgConfigNoFiles)
 	}
+	return nil
+}
 
-	section := parts[0]
-	field := parts[1]
-
-	v := reflect.ValueOf(cfg).Elem()
+func runConfigSchema(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
 
-	// セクションを見つける
-	sectionField := v.FieldByNameFunc(func(name string) bool {
-		return strings.EqualFold(name, section)
-	})
-	if !sectionField.IsValid() {
-		return fmt.Errorf("セクション '%s' が見つかりません", section)
+	data, err := config.SchemaJSON()
+	if err != nil {
+		return i18n.Errorf(i18n.MsgConfigSchemaFailed, err)
 	}
 
-	// フィールドを見つける
-	fieldValue := sectionField.FieldByNameFunc(func(name string) bool {
-		return strings.EqualFold(name, field) || strings.EqualFold(toSnakeCase(name), field)
-	})
-	if !fieldValue.IsValid() {
-		return fmt.Errorf("フィールド '%s' が見つかりません", field)
+	if configSchemaOutput == "" {
+		_, err := p.Stdout().Write(data)
+		return err
 	}
 
-	// 値を設定
-	switch fieldValue.Kind() {
-	case reflect.String:
-		fieldValue.SetString(value)
-	case reflect.Bool:
-		boolValue, err := strconv.ParseBool(value)
-		if err != nil {
-			return fmt.Errorf("無効なbool値です: %s", value)
-		}
-		fieldValue.SetBool(boolValue)
-	case reflect.Int, reflect.Int64:
-		intValue, err := strconv.ParseInt(value, 10, 64)
-		if err != nil {
-			return fmt.Errorf("無効な数値です: %s", value)
-		}
-		fieldValue.SetInt(intValue)
-	default:
-		return fmt.Errorf("サポートされていない型です: %v", fieldValue.Kind())
+	if err := os.MkdirAll(filepath.Dir(configSchemaOutput), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(configSchemaOutput, data, 0644); err != nil {
+		return err
 	}
 
+	p.Success(i18n.MsgConfigSchemaWritten, configSchemaOutput)
 	return nil
 }
 
-func printConfigSection(cfg *config.Config, indent string) {
+func printConfigSection(w io.Writer, cfg *config.Config, indent string) {
 	v := reflect.ValueOf(cfg).Elem()
 	t := v.Type()
 
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
-		fieldType := t.Field(i)
-
-		fmt.Printf("%s%s:\n", indent, strings.ToLower(fieldType.Name))
+		key := config.FieldKey(t.Field(i))
+		if key == "" {
+			continue
+		}
 
-		if field.Kind() == reflect.Struct {
-			printStructFields(field, indent+"  ")
+		switch {
+		case field.Kind() == reflect.Struct:
+			fmt.Fprintf(w, "%s%s:\n", indent, key)
+			printStructFields(w, field, indent+"  ")
+		case key == "profiles":
+			if len(cfg.Profiles) == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "%s%s:\n", indent, key)
+			for _, name := range cfg.ProfileNames() {
+				if name == cfg.ActiveProfile {
+					fmt.Fprintf(w, "%s  %s: %s\n", indent, name, i18n.Text(i18n.MsgConfigProfileActive))
+				} else {
+					fmt.Fprintf(w, "%s  %s:\n", indent, name)
+				}
+				printTable(w, cfg.Profiles[name], indent+"    ")
+			}
+		default:
+			fmt.Fprintf(w, "%s%s: %s\n", indent, key, config.FormatValue(field))
 		}
 	}
 }
 
-func printStructFields(v reflect.Value, indent string) {
+func printStructFields(w io.Writer, v reflect.Value, indent string) {
 	t := v.Type()
 
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
 		fieldType := t.Field(i)
-		tag := fieldType.Tag.Get("toml")
-		if tag == "" {
-			tag = toSnakeCase(fieldType.Name)
-		}
+		tag := config.FieldKey(fieldType)
 
-		fmt.Printf("%s%s: %v\n", indent, tag, field.Interface())
+		fmt.Fprintf(w, "%s%s: %s\n", indent, tag, config.FormatValue(field))
 	}
 }
 
-func toSnakeCase(s string) string {
-	var result strings.Builder
-	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result.WriteRune('_')
+// printTable は型を持たないテーブル（プロファイルなど）をキー順に表示する
+func printTable(w io.Writer, table map[string]interface{}, indent string) {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if nested, ok := table[key].(map[string]interface{}); ok {
+			fmt.Fprintf(w, "%s%s:\n", indent, key)
+			printTable(w, nested, indent+"  ")
+			continue
 		}
-		result.WriteRune(r)
+		fmt.Fprintf(w, "%s%s: %s\n", indent, key, config.FormatValue(reflect.ValueOf(table[key])))
 	}
-	return strings.ToLower(result.String())
 }
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/prompt"
+)
+
+// newPrompter は --yes と設定の ui.prompt_timeout を反映した Prompter を作成する
+// 質問は標準出力をパイプで渡しても表示されるよう標準エラー出力に書き込む
+func newPrompter(p *printer) *prompt.Prompter {
+	pr := prompt.New(p.Stderr())
+	if assumeYes {
+		pr.AssumeYes = true
+	}
+	pr.Timeout = time.Duration(GetConfig().UI.PromptTimeout) * time.Second
+	return pr
+}
+
+// confirmDestructive は破壊的な操作の前に確認を求め、続行してよいかどうかを返す
+// ui.confirm_destructive が無効な場合は確認せずに続行する
+// 端末でない場合や応答がタイムアウトした場合はエラーを返す
+func confirmDestructive(p *printer, message string) (bool, error) {
+	if !GetConfig().UI.ConfirmDestructive {
+		return true, nil
+	}
+
+	ok, err := newPrompter(p).Confirm(message, false)
+	switch {
+	case errors.Is(err, prompt.ErrNotInteractive):
+		return false, i18n.Errorf(i18n.MsgConfirmNotInteractive)
+	case errors.Is(err, prompt.ErrTimeout):
+		return false, i18n.Errorf(i18n.MsgConfirmTimeout, GetConfig().UI.PromptTimeout)
+	case err != nil:
+		return false, err
+	}
+	if !ok {
+		p.Info(i18n.MsgCancelled)
+	}
+	return ok, nil
+}
@@ -5,8 +5,10 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ongasatoshi/scion/internal/audit"
 	"github.com/ongasatoshi/scion/internal/git"
-	"github.com/ongasatoshi/scion/pkg/output"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/layout"
 	"github.com/spf13/cobra"
 )
 
@@ -14,49 +16,115 @@ var (
 	createBaseBranch string
 	createRemote     string
 	createForce      bool
+	createTrack      string
+	createPR         uint
+	createDetach     string
+	createPush       bool
+	createSparse     []string
+	createNoSparse   bool
+	// createPushSet は --push が指定されたかどうか（指定されていない場合は git.push_on_create を使う）
+	createPushSet bool
 )
 
+// prBranchFormat は --pr でブランチ名を省略した場合のローカルブランチ名
+const prBranchFormat = "pr/%d"
+
+// prRefFormat は --pr で取得したプルリクエストを保存するローカルの ref
+// refs/remotes 以下に置くとリモートブランチと誤認されるため、scion 専用の名前空間を使う
+const prRefFormat = "refs/scion/pr/%s/%d"
+
 var createCmd = &cobra.Command{
-	Use:   "create <branch-name>",
-	Short: "新しいworktreeブランチを作成",
-	Long: `create コマンドは新しいGit Worktreeブランチを作成し、専用のディレクトリに配置します。
-
-worktreeディレクトリはベースリポジトリと同一階層に作成されます。
-
-例:
-  scion create feature/new-feature
-  scion create feature/payment --base develop
-  scion create bugfix/issue-123 --remote upstream
-  scion create feature/refactor --force`,
-	Args: cobra.ExactArgs(1),
-	RunE: runCreate,
+	Use:   "create [branch-name]",
+	Short: i18n.CmdCreateShort,
+	Long:  i18n.CmdCreateLong,
+	Args: func(cmd *cobra.Command, args []string) error {
+		// --track 、--pr 、--detach ではブランチ名を省略できる
+		track, _ := cmd.Flags().GetString("track")
+		pr, _ := cmd.Flags().GetUint("pr")
+		detach, _ := cmd.Flags().GetString("detach")
+		if len(args) == 0 && track == "" && pr == 0 && detach == "" {
+			return i18n.Errorf(i18n.MsgCreateBranchRequired)
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE:              runCreate,
 }
 
 func init() {
 	rootCmd.AddCommand(createCmd)
 
-	createCmd.Flags().StringVarP(&createBaseBranch, "base", "b", "", "ベースブランチを指定 (デフォルト: 設定ファイルの値または現在のブランチ)")
-	createCmd.Flags().StringVarP(&createRemote, "remote", "r", "", "リモートリポジトリを指定 (デフォルト: origin)")
-	createCmd.Flags().BoolVarP(&createForce, "force", "f", false, "既存のworktreeを強制的に上書き")
+	createCmd.Flags().StringVarP(&createBaseBranch, "base", "b", "", i18n.FlagCreateBase)
+	createCmd.Flags().StringVarP(&createRemote, "remote", "r", "", i18n.FlagCreateRemote)
+	createCmd.Flags().BoolVarP(&createForce, "force", "f", false, i18n.FlagCreateForce)
+	createCmd.Flags().StringVarP(&createTrack, "track", "t", "", i18n.FlagCreateTrack)
+	createCmd.Flags().UintVar(&createPR, "pr", 0, i18n.FlagCreatePR)
+	createCmd.Flags().StringVarP(&createDetach, "detach", "d", "", i18n.FlagCreateDetach)
+	createCmd.Flags().BoolVarP(&createPush, "push", "p", false, i18n.FlagCreatePush)
+	createCmd.Flags().StringSliceVar(&createSparse, "sparse", nil, i18n.FlagCreateSparse)
+	createCmd.Flags().BoolVar(&createNoSparse, "no-sparse", false, i18n.FlagCreateNoSparse)
+	createCmd.MarkFlagsMutuallyExclusive("track", "pr", "detach")
+	createCmd.MarkFlagsMutuallyExclusive("track", "base")
+	createCmd.MarkFlagsMutuallyExclusive("pr", "base")
+	createCmd.MarkFlagsMutuallyExclusive("detach", "base")
+	createCmd.MarkFlagsMutuallyExclusive("track", "remote")
+	createCmd.MarkFlagsMutuallyExclusive("detach", "push")
+	createCmd.MarkFlagsMutuallyExclusive("sparse", "no-sparse")
+
+	createCmd.RegisterFlagCompletionFunc("base", completeBranches)
+	createCmd.RegisterFlagCompletionFunc("remote", completeRemotes)
+	createCmd.RegisterFlagCompletionFunc("track", completeRemoteBranches)
+	createCmd.RegisterFlagCompletionFunc("pr", cobra.NoFileCompletions)
+	createCmd.RegisterFlagCompletionFunc("detach", completeRevisions)
+	createCmd.RegisterFlagCompletionFunc("sparse", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	})
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
-	branchName := args[0]
+	p := printerFor(cmd)
 
 	// Gitリポジトリかどうか確認
 	if !git.IsGitRepository() {
-		return fmt.Errorf("Gitリポジトリ内で実行してください")
+		return git.Errorf(git.ErrNotGitRepo, i18n.MsgNotGitRepository)
+	}
+
+	createPushSet = cmd.Flags().Changed("push")
+
+	if createDetach != "" {
+		// 名前を省略した場合はリビジョンをそのまま名前にする
+		name := createDetach
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return createDetachedWorktree(p, createDetach, name)
+	}
+	if len(args) > 0 {
+		return createWorktree(p, args[0])
+	}
+	if createPR > 0 {
+		return createWorktree(p, fmt.Sprintf(prBranchFormat, createPR))
+	}
+	// --track のみの場合はリモート名を除いたブランチ名を使用する
+	_, branchName, ok := git.SplitRemoteBranch(createTrack)
+	if !ok {
+		return git.Errorf(git.ErrBranchNotFound, i18n.MsgCreateNotRemoteBranch, createTrack)
 	}
+	return createWorktree(p, branchName)
+}
 
-	// リポジトリルートを取得
-	repoRoot, err := git.GetRepositoryRoot()
+// createWorktree はブランチの worktree を作成し、post_create フックを実行する
+// 作成を試みた結果は監査ログに記録する
+func createWorktree(p *printer, branchName string) (err error) {
+	// 設定からデフォルト値を取得（ブランチ名に一致するルールを適用）
+	config, err := GetConfig().ForBranch(branchName)
 	if err != nil {
 		return err
 	}
-
-	// 設定からデフォルト値を取得
-	config := GetConfig()
-	baseDir := config.Worktree.BaseDir
+	rules, _ := GetConfig().MatchingRules(branchName)
+	for _, rule := range rules {
+		p.Info(i18n.MsgCreateApplyingRule, rule.Pattern)
+	}
 	baseBranch := createBaseBranch
 	remote := createRemote
 
@@ -67,56 +135,296 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	if remote == "" {
 		remote = config.Git.DefaultRemote
 	}
+	push := config.Git.PushOnCreate
+	if createPushSet {
+		push = createPush
+	}
+	if err := validateUpstreamMode(config.Git.UpstreamMode); err != nil {
+		return err
+	}
 
-	// fetch を実行（設定で有効な場合）
-	if config.Git.FetchBeforeCreate {
-		output.Info("リモートから最新の情報を取得しています...")
-		if err := git.Fetch(remote); err != nil {
-			output.Warning("fetchに失敗しました: %v", err)
+	// --track ではリモートを指定したリモートブランチから決める
+	var trackBranch string
+	if createTrack != "" {
+		var ok bool
+		if remote, trackBranch, ok = git.SplitRemoteBranch(createTrack); !ok {
+			return git.Errorf(git.ErrBranchNotFound, i18n.MsgCreateNotRemoteBranch, createTrack)
+		}
+	}
+
+	if createPR > 0 {
+		// プルリクエストの ref は通常の fetch では取得されないため、常に取得する
+		prRef, err := fetchPullRequest(p, config.Git.PullRequestRef, remote, createPR)
+		if err != nil {
+			return err
 		}
+		baseBranch = prRef
+	} else if config.Git.FetchBeforeCreate {
+		// fetch を実行（設定で有効な場合）
+		progress := p.StartProgress(i18n.Text(i18n.MsgCreateFetching))
+		err := git.Fetch(remote, progress)
+		progress.Stop()
+		if err != nil {
+			p.Warning(i18n.MsgCreateFetchFailed, err)
+		}
+	}
+
+	// リモートブランチを起点にする場合は、上流に設定して追跡する
+	// --base の指定がなく、ローカルにないブランチがリモートにある場合も同様に扱う
+	track := false
+	switch {
+	case trackBranch != "":
+		if !git.RemoteBranchExists(remote, trackBranch) {
+			return git.Errorf(git.ErrBranchNotFound, i18n.MsgCreateRemoteNotFound, createTrack)
+		}
+		track = true
+	case createPR == 0 && createBaseBranch == "" && !git.BranchExists(branchName) && git.RemoteBranchExists(remote, branchName):
+		trackBranch = branchName
+		track = true
+	}
+	if track {
+		baseBranch = "refs/remotes/" + remote + "/" + trackBranch
+		p.Info(i18n.MsgCreateTracking, remote+"/"+trackBranch)
 	}
 
-	// worktreeパスを構築
-	// ベースリポジトリの親ディレクトリに wtree ディレクトリを作成
-	parentDir := filepath.Dir(repoRoot)
+	// worktree.placement / dir_template からパスを決定（他のブランチの worktree と衝突する場合は番号を付ける）
+	worktreePath, err := allocateWorktreePath(p, config, branchName)
+	if err != nil {
+		return err
+	}
+	p.Verbose(i18n.MsgVerboseCreatePlan, baseBranch, remote, worktreePath)
 
-	// ブランチ名からパスセーフな名前を生成
-	safeBranchName := strings.ReplaceAll(branchName, "/", "-")
-	worktreePath := filepath.Join(parentDir, baseDir, safeBranchName)
+	entry := audit.Entry{Action: audit.ActionCreate, Branch: branchName, Path: worktreePath, Details: map[string]string{}}
+	if createForce {
+		entry.Details["force"] = "true"
+	}
+	if track {
+		entry.Details["track"] = remote + "/" + trackBranch
+	}
+	if createPR > 0 {
+		entry.Details["pr"] = fmt.Sprint(createPR)
+	}
+	sparse := sparsePaths(config.Worktree.SparsePaths)
+	if len(sparse) > 0 {
+		entry.Details["sparse"] = strings.Join(sparse, ",")
+	}
+	defer func() { recordAudit(p, entry, err) }()
 
 	// ブランチが既に存在するか確認
 	branchExists := git.BranchExists(branchName)
 	worktreeExists := git.WorktreeExists(worktreePath)
 
 	if worktreeExists && !createForce {
-		return fmt.Errorf("worktree '%s' は既に存在します\n--force オプションで上書きできます", worktreePath)
+		return git.Errorf(git.ErrWorktreeExists, i18n.MsgCreateWorktreeExists, worktreePath)
 	}
 
 	if branchExists && !createForce {
-		output.Warning("ブランチ '%s' は既に存在します。既存のブランチをチェックアウトします", branchName)
+		p.Warning(i18n.MsgCreateBranchExists, branchName)
 	}
 
 	// 強制モードで既存のworktreeがある場合は削除
 	if worktreeExists && createForce {
-		output.Info("既存のworktreeを削除しています...")
+		p.Info(i18n.MsgCreateRemovingExisting)
 		if err := git.RemoveWorktree(worktreePath, true); err != nil {
-			return fmt.Errorf("既存のworktreeの削除に失敗しました: %w", err)
+			return i18n.Errorf(i18n.MsgCreateRemoveFailed, err)
 		}
 	}
 
+	// 以前に取得したプルリクエストのブランチは、取得し直した ref まで進める
+	if createPR > 0 && branchExists {
+		if err := updatePullRequestBranch(p, branchName, baseBranch, createPR); err != nil {
+			return err
+		}
+	}
+
+	// リポジトリ内に作成する場合は未追跡のファイルとして表示されないよう除外する
+	excludeWorktreePath(p, worktreePath)
+
 	// worktree を作成
-	output.Info("worktreeを作成しています: %s", branchName)
-	if err := git.CreateWorktree(worktreePath, branchName, baseBranch, createForce); err != nil {
+	progress := p.StartProgress(i18n.T(i18n.MsgCreateCreating, branchName))
+	err = git.CreateWorktree(worktreePath, branchName, baseBranch, track, createForce, sparse, progress)
+	progress.Stop()
+	if err != nil {
 		return err
 	}
 
-	output.Success("Worktree ディレクトリを作成しました: %s", worktreePath)
+	entry.After, _ = git.HeadCommit(worktreePath)
+	updateWorktreeStore(p, func(store *layout.Store) {
+		store.Set(worktreePath, branchName)
+		// scion sync で取り込むベースを記録する
+		// リモートブランチの追跡とプルリクエストの ref は、ブランチ自身の取得元のため除く
+		if !branchExists && !track && createPR == 0 {
+			store.SetBase(worktreePath, baseBranch)
+		}
+	})
+
+	p.Success(i18n.MsgCreateCreatedDir, worktreePath)
+	if len(sparse) > 0 {
+		p.Info(i18n.MsgCreateSparse, strings.Join(sparse, ", "))
+	}
 	if !branchExists {
-		output.Success("ブランチ '%s' を作成してチェックアウトしました", branchName)
+		p.Success(i18n.MsgCreateCreatedBranch, branchName)
 	} else {
-		output.Success("ブランチ '%s' をチェックアウトしました", branchName)
+		p.Success(i18n.MsgCreateCheckedOutBranch, branchName)
+	}
+
+	// 新しく作成したブランチの上流を設定し、push する（リモートブランチやプルリクエストから作成した場合を除く）
+	if !branchExists && !track && createPR == 0 {
+		setupUpstream(p, config.Git.UpstreamMode, worktreePath, branchName, baseBranch, remote, push, entry.Details)
+	}
+	// フックでビルドなどを実行できるよう、サブモジュールと Git LFS のファイルを先に取得する
+	setupSubmodulesAndLFS(p, config, worktreePath, entry.Details)
+	p.Info(i18n.MsgCreatePath, worktreePath)
+
+	// post_create フックを実行
+	if err := runHooks(p, "post_create", config.Hooks.PostCreate, worktreePath, branchName); err != nil {
+		p.Warning("%v", err)
+	}
+
+	return nil
+}
+
+// createDetachedWorktree はブランチを作成せずに、リビジョン（コミットやタグ）を detached HEAD でチェックアウトした worktree を作成する
+// name は worktree のディレクトリ名と、clear などで worktree を指定する名前に使用する
+// ルールはブランチ名で選択するため適用しない
+func createDetachedWorktree(p *printer, revision, name string) (err error) {
+	config := GetConfig()
+	remote := createRemote
+	if remote == "" {
+		remote = config.Git.DefaultRemote
 	}
-	output.Info("パス: %s", worktreePath)
 
+	// fetch を実行（設定で有効な場合）
+	if config.Git.FetchBeforeCreate {
+		progress := p.StartProgress(i18n.Text(i18n.MsgCreateFetching))
+		err := git.Fetch(remote, progress)
+		progress.Stop()
+		if err != nil {
+			p.Warning(i18n.MsgCreateFetchFailed, err)
+		}
+	}
+
+	worktreePath, err := allocateWorktreePath(p, config, name)
+	if err != nil {
+		return err
+	}
+	p.Verbose(i18n.MsgVerboseCreatePlan, revision, remote, worktreePath)
+
+	entry := audit.Entry{Action: audit.ActionCreate, Branch: name, Path: worktreePath, Details: map[string]string{"detached": revision}}
+	if createForce {
+		entry.Details["force"] = "true"
+	}
+	sparse := sparsePaths(config.Worktree.SparsePaths)
+	if len(sparse) > 0 {
+		entry.Details["sparse"] = strings.Join(sparse, ",")
+	}
+	defer func() { recordAudit(p, entry, err) }()
+
+	worktreeExists := git.WorktreeExists(worktreePath)
+	if worktreeExists && !createForce {
+		return git.Errorf(git.ErrWorktreeExists, i18n.MsgCreateWorktreeExists, worktreePath)
+	}
+	if worktreeExists {
+		p.Info(i18n.MsgCreateRemovingExisting)
+		if err := git.RemoveWorktree(worktreePath, true); err != nil {
+			return i18n.Errorf(i18n.MsgCreateRemoveFailed, err)
+		}
+	}
+
+	excludeWorktreePath(p, worktreePath)
+
+	progress := p.StartProgress(i18n.T(i18n.MsgCreateCreatingDetached, revision))
+	err = git.CreateDetachedWorktree(worktreePath, revision, createForce, sparse, progress)
+	progress.Stop()
+	if err != nil {
+		return err
+	}
+
+	entry.After, _ = git.HeadCommit(worktreePath)
+	updateWorktreeStore(p, func(store *layout.Store) { store.SetDetached(worktreePath, name) })
+
+	p.Success(i18n.MsgCreateCreatedDir, worktreePath)
+	if len(sparse) > 0 {
+		p.Info(i18n.MsgCreateSparse, strings.Join(sparse, ", "))
+	}
+	head := entry.After
+	if len(head) > shortHeadLength {
+		head = head[:shortHeadLength]
+	}
+	p.Success(i18n.MsgCreateDetachedAt, revision, head)
+	setupSubmodulesAndLFS(p, config, worktreePath, entry.Details)
+	p.Info(i18n.MsgCreatePath, worktreePath)
+
+	if err := runHooks(p, "post_create", config.Hooks.PostCreate, worktreePath, ""); err != nil {
+		p.Warning("%v", err)
+	}
+	return nil
+}
+
+// sparsePaths は sparse checkout でチェックアウトするディレクトリを返す
+// --sparse の指定を優先し、なければ設定の worktree.sparse_paths を使用する（--no-sparse の場合は使用しない）
+func sparsePaths(configured []string) []string {
+	if createNoSparse {
+		return nil
+	}
+	paths := configured
+	if len(createSparse) > 0 {
+		paths = createSparse
+	}
+	// cone モードではリポジトリのルートからのディレクトリで指定するため、前後の / を取り除く
+	var cleaned []string
+	for _, path := range paths {
+		path = strings.Trim(filepath.ToSlash(path), "/")
+		if path != "" && path != "." {
+			cleaned = append(cleaned, path)
+		}
+	}
+	return cleaned
+}
+
+// updatePullRequestBranch は既存のローカルブランチを取得したプルリクエストの ref まで早送りする
+// ブランチにプルリクエストに含まれないコミットがある場合は、コミットを失わないよう変更せずにエラーを返す
+// チェックアウトしている worktree のファイルは更新できないため、他の worktree でチェックアウト済みの場合もエラーを返す
+func updatePullRequestBranch(p *printer, branchName, prRef string, number uint) error {
+	if wt, ok := git.WorktreeForBranch(branchName); ok {
+		return git.Errorf(git.ErrWorktreeExists, i18n.MsgGitBranchCheckedOut, branchName, wt.Path)
+	}
+
+	current, err := git.ResolveCommit("refs/heads/" + branchName)
+	if err != nil {
+		return err
+	}
+	fetched, err := git.ResolveCommit(prRef)
+	if err != nil {
+		return err
+	}
+	if current == fetched {
+		return nil
+	}
+	if !git.IsAncestorOf(current, fetched) {
+		return i18n.Errorf(i18n.MsgCreatePRDiverged, branchName, number)
+	}
+	if err := git.UpdateBranch(branchName, fetched, current); err != nil {
+		return err
+	}
+	p.Info(i18n.MsgCreatePRUpdated, branchName, number)
 	return nil
 }
+
+// fetchPullRequest はプルリクエストの ref をリモートから取得し、保存したローカルの ref を返す
+// refPattern は git.pull_request_ref の値で、* をプルリクエストの番号に置き換えて使用する
+func fetchPullRequest(p *printer, refPattern, remote string, number uint) (string, error) {
+	if strings.Count(refPattern, "*") != 1 {
+		return "", i18n.Errorf(i18n.MsgCreatePRRefInvalid, refPattern)
+	}
+	src := strings.Replace(refPattern, "*", fmt.Sprint(number), 1)
+	dst := fmt.Sprintf(prRefFormat, remote, number)
+
+	progress := p.StartProgress(i18n.T(i18n.MsgCreateFetchingPR, number, remote))
+	err := git.FetchRef(remote, src, dst, progress)
+	progress.Stop()
+	if err != nil {
+		return "", err
+	}
+	return dst, nil
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ongasatoshi/scion/internal/config"
+	"github.com/ongasatoshi/scion/internal/git"
+)
+
+// setupTestRepo は main ブランチに1つのコミットを持つリポジトリと、origin として登録したローカルのbareリポジトリを作成する
+// カレントディレクトリをリポジトリに移動し、設定をデフォルト値にする（テスト終了時に元に戻す）
+// 監査ログはテスト用の一時ディレクトリに書き込む
+func setupTestRepo(t *testing.T) (repoDir, remoteDir string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	repoDir = filepath.Join(dir, "repo")
+	remoteDir = filepath.Join(dir, "remote.git")
+	t.Setenv("XDG_STATE_HOME", filepath.Join(dir, "state"))
+
+	runGit(t, dir, "init", "--bare", "-b", "main", remoteDir)
+	runGit(t, dir, "init", "-b", "main", repoDir)
+	runGit(t, repoDir, "config", "user.email", "test@example.com")
+	runGit(t, repoDir, "config", "user.name", "Test User")
+	commitFile(t, repoDir, "test.txt", "test content", "Initial commit")
+	runGit(t, repoDir, "remote", "add", "origin", remoteDir)
+	runGit(t, repoDir, "push", "origin", "main")
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	originalCfg := cfg
+	cfg = config.DefaultConfig()
+	t.Cleanup(func() {
+		os.Chdir(originalDir)
+		cfg = originalCfg
+	})
+	return repoDir, remoteDir
+}
+
+// runGit は dir で git コマンドを実行し、標準出力を返す
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		stderr := ""
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr = string(exitErr.Stderr)
+		}
+		t.Fatalf("git %v failed: %v\n%s", args, err, stderr)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commitFile は dir のファイルに内容を書き込んでコミットし、コミットのハッシュを返す
+func commitFile(t *testing.T, dir, name, content, message string) string {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-m", message)
+	return runGit(t, dir, "rev-parse", "HEAD")
+}
+
+// resetCreateFlags は create のフラグをテスト終了時にデフォルト値に戻す
+func resetCreateFlags(t *testing.T) {
+	t.Cleanup(func() {
+		createBaseBranch, createRemote, createTrack, createDetach = "", "", "", ""
+		createForce, createPush, createPushSet, createNoSparse = false, false, false, false
+		createPR = 0
+		createSparse = nil
+	})
+}
+
+func TestCreateTracksRemoteOnlyBranch(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	resetCreateFlags(t)
+
+	// リモートにだけ存在するブランチを作成する
+	runGit(t, repoDir, "switch", "-c", "feature/remote")
+	remoteHead := commitFile(t, repoDir, "remote.txt", "remote", "Remote commit")
+	runGit(t, repoDir, "push", "origin", "feature/remote")
+	runGit(t, repoDir, "switch", "main")
+	runGit(t, repoDir, "branch", "-D", "feature/remote")
+	runGit(t, repoDir, "update-ref", "-d", "refs/remotes/origin/feature/remote")
+
+	p, out, _ := newTestPrinter()
+	if err := createWorktree(p, "feature/remote"); err != nil {
+		t.Fatalf("createWorktree returned error: %v", err)
+	}
+
+	wt, ok := git.WorktreeForBranch("feature/remote")
+	if !ok {
+		t.Fatal("expected worktree for feature/remote")
+	}
+	if head, _ := git.HeadCommit(wt.Path); head != remoteHead {
+		t.Errorf("expected worktree at the remote branch %s, got %s", remoteHead, head)
+	}
+	if upstream := runGit(t, wt.Path, "rev-parse", "--abbrev-ref", "@{upstream}"); upstream != "origin/feature/remote" {
+		t.Errorf("expected upstream origin/feature/remote, got %q", upstream)
+	}
+	if !strings.Contains(out.String(), "origin/feature/remote") {
+		t.Errorf("expected tracking message, got %q", out.String())
+	}
+
+	// リモートブランチの追跡ではベースを記録しない
+	store, err := openWorktreeStore()
+	if err != nil {
+		t.Fatalf("failed to open worktree store: %v", err)
+	}
+	if base, ok := store.Base(wt.Path); ok {
+		t.Errorf("expected no recorded base for tracking branch, got %q", base)
+	}
+}
+
+func TestCreatePullRequestUpdatesExistingBranch(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	resetCreateFlags(t)
+	createPR = 5
+
+	// プルリクエストを作成し、取得する
+	runGit(t, repoDir, "switch", "-c", "contributor")
+	first := commitFile(t, repoDir, "pr.txt", "v1", "PR v1")
+	runGit(t, repoDir, "push", "origin", "HEAD:refs/pull/5/head")
+
+	if err := createWorktree(discardPrinter(), "pr/5"); err != nil {
+		t.Fatalf("createWorktree returned error: %v", err)
+	}
+	wt, ok := git.WorktreeForBranch("pr/5")
+	if !ok {
+		t.Fatal("expected worktree for pr/5")
+	}
+	runGit(t, repoDir, "worktree", "remove", wt.Path)
+
+	// プルリクエストが更新された場合は、既存のブランチを早送りする
+	second := commitFile(t, repoDir, "pr.txt", "v2", "PR v2")
+	runGit(t, repoDir, "push", "origin", "HEAD:refs/pull/5/head")
+
+	p, out, _ := newTestPrinter()
+	if err := createWorktree(p, "pr/5"); err != nil {
+		t.Fatalf("createWorktree returned error: %v", err)
+	}
+	wt, _ = git.WorktreeForBranch("pr/5")
+	if head, _ := git.HeadCommit(wt.Path); head != second {
+		t.Errorf("expected pr/5 to be fast-forwarded to %s, got %s (previous %s)", second, head, first)
+	}
+	if !strings.Contains(out.String(), "#5") {
+		t.Errorf("expected fast-forward message, got %q", out.String())
+	}
+
+	runGit(t, repoDir, "worktree", "remove", wt.Path)
+
+	// プルリクエストを強制 push で書き換える
+	runGit(t, repoDir, "commit", "--amend", "-m", "PR v2 (rewritten)")
+	runGit(t, repoDir, "push", "--force", "origin", "HEAD:refs/pull/5/head")
+
+	// 他の worktree でチェックアウトしているブランチは移動しない
+	other := filepath.Join(t.TempDir(), "other")
+	runGit(t, repoDir, "worktree", "add", other, "pr/5")
+	if err := createWorktree(discardPrinter(), "pr/5"); !errors.Is(err, git.ErrWorktreeExists) {
+		t.Errorf("expected ErrWorktreeExists while pr/5 is checked out, got %v", err)
+	}
+	runGit(t, repoDir, "worktree", "remove", other)
+
+	// 強制 push でブランチと分岐した場合は、ローカルのコミットを失わないよう中止する
+	err := createWorktree(discardPrinter(), "pr/5")
+	if err == nil {
+		t.Fatal("expected error for diverged pull request branch")
+	}
+	if !strings.Contains(err.Error(), "pr/5") {
+		t.Errorf("expected error to name the branch, got %v", err)
+	}
+	if head := runGit(t, repoDir, "rev-parse", "refs/heads/pr/5"); head != second {
+		t.Errorf("expected pr/5 to stay at %s, got %s", second, head)
+	}
+	if _, ok := git.WorktreeForBranch("pr/5"); ok {
+		t.Error("expected no worktree for diverged pr/5")
+	}
+}
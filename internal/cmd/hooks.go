@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// runHooks はフックコマンドを worktree ディレクトリ内で順番に実行する
+// コマンドはシェル経由で実行され、SCION_BRANCH と SCION_WORKTREE_PATH が環境変数として渡される
+func runHooks(p *printer, name string, commands []string, worktreePath, branchName string) error {
+	for _, command := range commands {
+		p.Info(i18n.MsgHookRunning, name, command)
+
+		execCmd := exec.Command("sh", "-c", command)
+		execCmd.Dir = worktreePath
+		execCmd.Stdin = os.Stdin
+		execCmd.Stdout = p.Stdout()
+		execCmd.Stderr = p.Stderr()
+		execCmd.Env = append(os.Environ(),
+			"SCION_BRANCH="+branchName,
+			"SCION_WORKTREE_PATH="+worktreePath,
+		)
+
+		if err := execCmd.Run(); err != nil {
+			return i18n.Errorf(i18n.MsgHookFailed, name, command, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunHooksEnvAndWorkingDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	commands := []string{
+		`printf '%s\n%s\n' "$SCION_BRANCH" "$SCION_WORKTREE_PATH" > env.txt`,
+		"pwd -P > pwd.txt",
+	}
+	if err := runHooks(discardPrinter(), "post_create", commands, dir, "feature/login"); err != nil {
+		t.Fatalf("runHooks returned error: %v", err)
+	}
+
+	env, err := os.ReadFile(filepath.Join(dir, "env.txt"))
+	if err != nil {
+		t.Fatalf("expected hook to run in the worktree directory: %v", err)
+	}
+	if expected := "feature/login\n" + dir + "\n"; string(env) != expected {
+		t.Errorf("expected SCION_BRANCH and SCION_WORKTREE_PATH %q, got %q", expected, env)
+	}
+
+	pwd, err := os.ReadFile(filepath.Join(dir, "pwd.txt"))
+	if err != nil {
+		t.Fatalf("failed to read pwd.txt: %v", err)
+	}
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatalf("failed to resolve %s: %v", dir, err)
+	}
+	if got := strings.TrimSpace(string(pwd)); got != resolved {
+		t.Errorf("expected working directory %q, got %q", resolved, got)
+	}
+}
+
+func TestRunHooksStopsOnFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	err := runHooks(discardPrinter(), "pre_clear", []string{"exit 3", "touch after.txt"}, dir, "feature/login")
+	if err == nil {
+		t.Fatal("expected error for failing hook")
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("expected exit status 3 to be wrapped, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "exit 3") {
+		t.Errorf("expected error to name the failing command, got %v", err)
+	}
+
+	// 失敗したコマンドより後のコマンドは実行しない
+	if _, err := os.Stat(filepath.Join(dir, "after.txt")); !os.IsNotExist(err) {
+		t.Error("expected commands after the failing hook to be skipped")
+	}
+}
+
+func TestRunHooksWritesToPrinter(t *testing.T) {
+	p, stdout, stderr := newTestPrinter()
+
+	if err := runHooks(p, "post_create", []string{"echo out", "echo err >&2"}, t.TempDir(), "main"); err != nil {
+		t.Fatalf("runHooks returned error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "out\n") {
+		t.Errorf("expected hook stdout to be written to the printer, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "err\n") {
+		t.Errorf("expected hook stderr to be written to the printer, got %q", stderr.String())
+	}
+}
+
+func TestRunHooksEmpty(t *testing.T) {
+	if err := runHooks(discardPrinter(), "post_create", nil, t.TempDir(), "main"); err != nil {
+		t.Errorf("expected no error without commands, got %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/ongasatoshi/scion/internal/audit"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/layout"
+	"github.com/ongasatoshi/scion/internal/picker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	landSquash   bool
+	landRebase   bool
+	landFFOnly   bool
+	landClear    bool
+	landNoVerify bool
+)
+
+// ベースブランチへの取り込み方
+const (
+	landModeMerge  = "merge"
+	landModeSquash = "squash"
+	landModeRebase = "rebase"
+	landModeFFOnly = "ff-only"
+)
+
+var landCmd = &cobra.Command{
+	Use:               "land [branch-name]",
+	Short:             i18n.CmdLandShort,
+	Long:              i18n.CmdLandLong,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeBranches(false),
+	RunE:              runLand,
+}
+
+func init() {
+	rootCmd.AddCommand(landCmd)
+
+	landCmd.Flags().BoolVar(&landSquash, "squash", false, i18n.FlagLandSquash)
+	landCmd.Flags().BoolVar(&landRebase, "rebase", false, i18n.FlagLandRebase)
+	landCmd.Flags().BoolVar(&landFFOnly, "ff-only", false, i18n.FlagLandFFOnly)
+	landCmd.Flags().BoolVar(&landClear, "clear", false, i18n.FlagLandClear)
+	landCmd.Flags().BoolVar(&landNoVerify, "no-verify", false, i18n.FlagLandNoVerify)
+	landCmd.MarkFlagsMutuallyExclusive("squash", "rebase", "ff-only")
+}
+
+func runLand(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
+	// Gitリポジトリかどうか確認
+	if !git.IsGitRepository() {
+		return git.Errorf(git.ErrNotGitRepo, i18n.MsgNotGitRepository)
+	}
+
+	wt, err := landWorktreeArg(args)
+	if errors.Is(err, picker.ErrCancelled) {
+		p.Info(i18n.MsgCancelled)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := landWorktree(p, wt); err != nil {
+		return err
+	}
+
+	if landClear {
+		// 取り込んだブランチはベースに含まれているが、スカッシュした場合などはマージ済みと判定されないため強制的に削除する
+		return clearWorktreeByPath(p, wt.Path, wt.Branch, true, true)
+	}
+	return nil
+}
+
+// landWorktreeArg は引数で指定されたブランチの worktree を返す
+// 引数が省略された場合は、端末であればメインworktree以外からピッカーで選択させる
+func landWorktreeArg(args []string) (git.WorktreeInfo, error) {
+	var wt git.WorktreeInfo
+	if len(args) > 0 {
+		found, err := findWorktree(args[0])
+		if err != nil {
+			return git.WorktreeInfo{}, err
+		}
+		wt = found
+	} else {
+		if !picker.Available() {
+			return git.WorktreeInfo{}, i18n.Errorf(i18n.MsgBranchRequired)
+		}
+		selected, err := pickWorktrees(false, false)
+		if err != nil {
+			return git.WorktreeInfo{}, err
+		}
+		wt = selected[0]
+	}
+
+	mainRoot, err := git.GetMainWorktreeRoot()
+	if err != nil {
+		return git.WorktreeInfo{}, err
+	}
+	if filepath.Clean(wt.Path) == filepath.Clean(mainRoot) {
+		return git.WorktreeInfo{}, i18n.Errorf(i18n.MsgLandMainWorktree)
+	}
+	return wt, nil
+}
+
+// landMode はフラグから取り込み方を返す
+func landMode() string {
+	switch {
+	case landSquash:
+		return landModeSquash
+	case landRebase:
+		return landModeRebase
+	case landFFOnly:
+		return landModeFFOnly
+	}
+	return landModeMerge
+}
+
+// landWorktree は worktree のブランチをベースブランチに取り込む
+// ベースブランチをチェックアウトしている worktree に変更がなければその worktree で取り込み、
+// それ以外の場合はベースブランチのコミットをチェックアウトした一時的な worktree で取り込んでからベースブランチを進める
+// 取り込みを試みた結果は、ベースブランチの取り込み前後のコミットとともに監査ログに記録する
+func landWorktree(p *printer, wt git.WorktreeInfo) (err error) {
+	if wt.Detached {
+		return git.Errorf(git.ErrBranchNotFound, i18n.MsgWorktreeNotFoundForBranch, filepath.Base(wt.Path))
+	}
+	branchName := wt.Branch
+
+	cfg, err := GetConfig().ForBranch(branchName)
+	if err != nil {
+		return err
+	}
+	store, _ := openWorktreeStore()
+	baseBranch, err := landBase(worktreeBase(wt.Path, store, cfg))
+	if err != nil {
+		return err
+	}
+	if baseBranch == branchName {
+		return i18n.Errorf(i18n.MsgLandSameBase, branchName)
+	}
+
+	mode := landMode()
+	entry := audit.Entry{
+		Action:  audit.ActionLand,
+		Branch:  branchName,
+		Path:    wt.Path,
+		Details: map[string]string{"mode": mode, "base": baseBranch},
+	}
+	defer func() { recordAudit(p, entry, err) }()
+
+	// 未コミットの変更は取り込まれないため、取り込む前にコミットさせる
+	hasChanges, err := git.HasUncommittedChanges(wt.Path)
+	if err != nil {
+		return err
+	}
+	if hasChanges {
+		return git.Errorf(git.ErrUncommittedChanges, i18n.MsgLandUncommittedChanges, branchName)
+	}
+
+	target, cleanup, err := landTarget(p, baseBranch, git.SparsePaths(wt.Path))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	entry.Details["into"] = target.path
+	entry.Before = target.before
+
+	if git.IsAncestor(target.path, branchName) {
+		p.Info(i18n.MsgLandAlreadyMerged, branchName, baseBranch)
+		entry.After = entry.Before
+		return nil
+	}
+
+	if mode == landModeRebase {
+		progress := p.StartProgress(i18n.T(i18n.MsgLandRebasing, branchName, baseBranch))
+		err = git.Rebase(wt.Path, baseBranch)
+		progress.Stop()
+		if errors.Is(err, git.ErrConflict) {
+			return git.Errorf(git.ErrConflict, i18n.MsgLandConflict, branchName, baseBranch)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// テストなどは取り込むコミット（リベースした場合はリベース後のコミット）で実行する
+	if landNoVerify {
+		entry.Details["verify"] = "skipped"
+	} else if err := runHooks(p, "pre_land", cfg.Hooks.PreLand, wt.Path, branchName); err != nil {
+		return err
+	}
+
+	progress := p.StartProgress(i18n.T(i18n.MsgLandLanding, branchName, baseBranch, mode))
+	switch mode {
+	case landModeSquash:
+		err = git.MergeSquash(target.path, branchName)
+	case landModeRebase, landModeFFOnly:
+		err = git.MergeFastForward(target.path, branchName)
+	default:
+		err = git.Merge(target.path, branchName)
+	}
+	progress.Stop()
+	if errors.Is(err, git.ErrNothingToSquash) {
+		p.Info(i18n.MsgLandAlreadyMerged, branchName, baseBranch)
+		entry.After = entry.Before
+		return nil
+	}
+	if errors.Is(err, git.ErrConflict) {
+		return git.Errorf(git.ErrConflict, i18n.MsgLandConflict, branchName, baseBranch)
+	}
+	if err != nil {
+		return err
+	}
+
+	entry.After, err = git.HeadCommit(target.path)
+	if err != nil {
+		return err
+	}
+	if err := target.advance(baseBranch, entry.After); err != nil {
+		entry.After = entry.Before
+		return err
+	}
+	p.Success(i18n.MsgLandLanded, branchName, baseBranch)
+	return nil
+}
+
+// landBase は取り込み先のローカルブランチを返す
+// ベースが origin/main のようなリモート追跡ブランチの場合は、同名のローカルブランチに取り込む
+func landBase(base string) (string, error) {
+	if git.BranchExists(base) {
+		return base, nil
+	}
+	if _, branchName, ok := git.SplitRemoteBranch(base); ok && git.BranchExists(branchName) {
+		return branchName, nil
+	}
+	return "", git.Errorf(git.ErrBranchNotFound, i18n.MsgLandBaseNotBranch, base)
+}
+
+// landDestination はベースブランチに取り込む worktree
+type landDestination struct {
+	// path は取り込む worktree のパス
+	path string
+	// before は取り込む前のベースブランチのコミット
+	before string
+	// temporary は path がベースブランチのコミットを detached HEAD でチェックアウトした一時的な worktree かどうか
+	temporary bool
+	// checkout はベースブランチをチェックアウトしている worktree のパス（temporary の場合のみ、なければ空）
+	checkout string
+}
+
+// landTarget はベースブランチに取り込む worktree と、取り込み後に呼ぶ後始末の関数を返す
+// ベースブランチをチェックアウトしている worktree の追跡しているファイルに変更がなければその worktree を使用し、
+// 変更がある場合やどこにもチェックアウトされていない場合は、ベースブランチのコミットを detached HEAD でチェックアウトした一時的な worktree を作成する
+// 一時的な worktree は、取り込む worktree と同じディレクトリだけを sparse checkout でチェックアウトする
+func landTarget(p *printer, baseBranch string, sparse []string) (landDestination, func(), error) {
+	before, err := git.ResolveCommit("refs/heads/" + baseBranch)
+	if err != nil {
+		return landDestination{}, nil, err
+	}
+	target := landDestination{before: before, temporary: true}
+
+	if wt, ok := git.WorktreeForBranch(baseBranch); ok {
+		hasChanges, err := git.HasTrackedChanges(wt.Path)
+		if err != nil {
+			return landDestination{}, nil, err
+		}
+		if !hasChanges {
+			return landDestination{path: wt.Path, before: before}, func() {}, nil
+		}
+		target.checkout = wt.Path
+	}
+
+	dir, err := os.MkdirTemp("", "scion-land-")
+	if err != nil {
+		return landDestination{}, nil, err
+	}
+	target.path = filepath.Join(dir, layout.Slug(baseBranch))
+	p.Info(i18n.MsgLandTemporary, baseBranch, target.path)
+	if err := git.CreateDetachedWorktree(target.path, before, false, sparse, nil); err != nil {
+		os.RemoveAll(dir)
+		return landDestination{}, nil, err
+	}
+
+	cleanup := func() {
+		if err := git.RemoveWorktree(target.path, true); err != nil {
+			p.Warning(i18n.MsgLandTemporaryFailed, err)
+		}
+		os.RemoveAll(dir)
+	}
+	return target, cleanup, nil
+}
+
+// advance は一時的な worktree で取り込んだ場合に、ベースブランチを取り込んだコミット after に進める
+// ベースブランチをチェックアウトしている worktree がある場合は、未コミットの変更を残したまま早送りする（変更したファイルが重なる場合は何も変更せずにエラー）
+// チェックアウトされていない場合は、取り込む前のコミットから変わっていないことを確認して ref を更新する
+func (d landDestination) advance(baseBranch, after string) error {
+	if !d.temporary {
+		return nil
+	}
+	if d.checkout != "" {
+		if err := git.MergeFastForward(d.checkout, after); err != nil {
+			return git.Errorf(git.ErrUncommittedChanges, i18n.MsgLandBaseDirty, baseBranch, d.checkout, err)
+		}
+		return nil
+	}
+	return git.UpdateBranch(baseBranch, after, d.before)
+}
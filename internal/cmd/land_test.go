@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// resetLandFlags は land のフラグをテスト終了時にデフォルト値に戻す
+func resetLandFlags(t *testing.T) {
+	t.Cleanup(func() {
+		landSquash, landRebase, landFFOnly, landClear, landNoVerify = false, false, false, false, false
+	})
+}
+
+// setupLandWorktree は main をベースにした feature/x の worktree を作成し、ファイルをコミットしてパスを返す
+func setupLandWorktree(t *testing.T, name, content string) string {
+	t.Helper()
+	resetCreateFlags(t)
+	resetLandFlags(t)
+	if err := createWorktree(discardPrinter(), "feature/x"); err != nil {
+		t.Fatalf("failed to create feature/x: %v", err)
+	}
+	path := worktreePathForBranch(t, "feature/x")
+	commitFile(t, path, name, content, "Feature change")
+	return path
+}
+
+// worktreeCount は登録されている worktree の数を返す
+func worktreeCount(t *testing.T) int {
+	t.Helper()
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		t.Fatalf("failed to list worktrees: %v", err)
+	}
+	return len(worktrees)
+}
+
+func TestLandRunsPreLandAfterRebase(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	wtPath := setupLandWorktree(t, "feature.txt", "feature")
+	commitFile(t, repoDir, "main.txt", "main", "Main change")
+
+	hookDir := t.TempDir()
+	cfg.Hooks.PreLand = []string{"git rev-parse HEAD > " + filepath.Join(hookDir, "tested")}
+	landRebase = true
+
+	if _, err := runCommandForTest(runLand, "feature/x"); err != nil {
+		t.Fatalf("runLand returned error: %v", err)
+	}
+
+	head, _ := git.HeadCommit(wtPath)
+	if main := runGit(t, repoDir, "rev-parse", "main"); main != head {
+		t.Errorf("expected main to be fast-forwarded to the rebased branch %s, got %s", head, main)
+	}
+	tested, err := os.ReadFile(filepath.Join(hookDir, "tested"))
+	if err != nil {
+		t.Fatalf("expected pre_land hook to run: %v", err)
+	}
+	if strings.TrimSpace(string(tested)) != head {
+		t.Errorf("expected pre_land to run on the rebased commit %s, got %s", head, tested)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "feature.txt")); err != nil {
+		t.Errorf("expected landed file in the main worktree: %v", err)
+	}
+}
+
+func TestLandUsesTemporaryWorktreeWhenBaseIsDirty(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	setupLandWorktree(t, "feature.txt", "feature")
+	before := runGit(t, repoDir, "rev-parse", "main")
+	count := worktreeCount(t)
+
+	// 取り込むファイルと重ならない未コミットの変更は残す
+	if err := os.WriteFile(filepath.Join(repoDir, "test.txt"), []byte("local edit"), 0644); err != nil {
+		t.Fatalf("failed to modify main worktree: %v", err)
+	}
+
+	p, out, _ := newTestPrinter()
+	if err := landWorktree(p, mustFindWorktree(t, "feature/x")); err != nil {
+		t.Fatalf("landWorktree returned error: %v", err)
+	}
+
+	after := runGit(t, repoDir, "rev-parse", "main")
+	if after == before || !git.IsAncestorOf("feature/x", "main") {
+		t.Errorf("expected main to contain feature/x, got %s (before %s)", after, before)
+	}
+	if head := runGit(t, repoDir, "rev-parse", "HEAD"); head != after {
+		t.Errorf("expected main worktree HEAD to follow main %s, got %s", after, head)
+	}
+	if data, _ := os.ReadFile(filepath.Join(repoDir, "test.txt")); string(data) != "local edit" {
+		t.Errorf("expected uncommitted change to be kept, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "feature.txt")); err != nil {
+		t.Errorf("expected landed file in the main worktree: %v", err)
+	}
+	if status := runGit(t, repoDir, "status", "--porcelain"); status != "M test.txt" {
+		t.Errorf("expected only the local edit to remain, got %q", status)
+	}
+	if !strings.Contains(out.String(), "scion-land-") {
+		t.Errorf("expected temporary worktree message, got %q", out.String())
+	}
+	if got := worktreeCount(t); got != count {
+		t.Errorf("expected temporary worktree to be removed, got %d worktrees (want %d)", got, count)
+	}
+}
+
+func TestLandRefusesWhenBaseChangesOverlap(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	setupLandWorktree(t, "test.txt", "feature")
+	before := runGit(t, repoDir, "rev-parse", "main")
+
+	if err := os.WriteFile(filepath.Join(repoDir, "test.txt"), []byte("local edit"), 0644); err != nil {
+		t.Fatalf("failed to modify main worktree: %v", err)
+	}
+
+	err := landWorktree(discardPrinter(), mustFindWorktree(t, "feature/x"))
+	if !errors.Is(err, git.ErrUncommittedChanges) {
+		t.Fatalf("expected ErrUncommittedChanges, got %v", err)
+	}
+	if after := runGit(t, repoDir, "rev-parse", "main"); after != before {
+		t.Errorf("expected main to stay at %s, got %s", before, after)
+	}
+	if data, _ := os.ReadFile(filepath.Join(repoDir, "test.txt")); string(data) != "local edit" {
+		t.Errorf("expected uncommitted change to be kept, got %q", data)
+	}
+}
+
+func TestLandUpdatesBaseNotCheckedOut(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	setupLandWorktree(t, "feature.txt", "feature")
+	runGit(t, repoDir, "switch", "-c", "other")
+	landSquash = true
+
+	if err := landWorktree(discardPrinter(), mustFindWorktree(t, "feature/x")); err != nil {
+		t.Fatalf("landWorktree returned error: %v", err)
+	}
+
+	// スカッシュしたコミットが main に追加され、作業中のブランチは変更しない
+	if msg := runGit(t, repoDir, "log", "-1", "--format=%s", "main"); !strings.Contains(msg, "Squashed commit") {
+		t.Errorf("expected squashed commit on main, got %q", msg)
+	}
+	if parents := runGit(t, repoDir, "rev-list", "--count", "main"); parents != "2" {
+		t.Errorf("expected a single squashed commit on top of main, got %s commits", parents)
+	}
+	if branch := runGit(t, repoDir, "branch", "--show-current"); branch != "other" {
+		t.Errorf("expected main worktree to stay on other, got %s", branch)
+	}
+}
+
+func TestLandAlreadyMerged(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	resetCreateFlags(t)
+	resetLandFlags(t)
+	if err := createWorktree(discardPrinter(), "feature/x"); err != nil {
+		t.Fatalf("failed to create feature/x: %v", err)
+	}
+	before := runGit(t, repoDir, "rev-parse", "main")
+	hookDir := t.TempDir()
+	cfg.Hooks.PreLand = []string{"touch " + filepath.Join(hookDir, "ran")}
+
+	p, out, _ := newTestPrinter()
+	if err := landWorktree(p, mustFindWorktree(t, "feature/x")); err != nil {
+		t.Fatalf("landWorktree returned error: %v", err)
+	}
+	if after := runGit(t, repoDir, "rev-parse", "main"); after != before {
+		t.Errorf("expected main to stay at %s, got %s", before, after)
+	}
+	if !strings.Contains(out.String(), "feature/x") {
+		t.Errorf("expected already merged message, got %q", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(hookDir, "ran")); err == nil {
+		t.Error("expected pre_land not to run when there is nothing to land")
+	}
+}
+
+func TestLandSquashAlreadyApplied(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	wtPath := setupLandWorktree(t, "feature.txt", "feature")
+	feature, _ := git.HeadCommit(wtPath)
+	// 同じ変更を別のコミットの上にチェリーピックで取り込み、feature/x を祖先でなくする
+	commitFile(t, repoDir, "main.txt", "main", "Main change")
+	runGit(t, repoDir, "cherry-pick", feature)
+	before := runGit(t, repoDir, "rev-parse", "main")
+	landSquash = true
+
+	p, out, _ := newTestPrinter()
+	if err := landWorktree(p, mustFindWorktree(t, "feature/x")); err != nil {
+		t.Fatalf("landWorktree returned error: %v", err)
+	}
+	if after := runGit(t, repoDir, "rev-parse", "main"); after != before {
+		t.Errorf("expected main to stay at %s, got %s", before, after)
+	}
+	if !strings.Contains(out.String(), i18n.T(i18n.MsgLandAlreadyMerged, "feature/x", "main")) {
+		t.Errorf("expected already merged message, got %q", out.String())
+	}
+	if status := runGit(t, repoDir, "status", "--porcelain"); status != "" {
+		t.Errorf("expected clean main worktree, got %q", status)
+	}
+}
+
+func TestLandConflict(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	wtPath := setupLandWorktree(t, "test.txt", "feature")
+	mainHead := commitFile(t, repoDir, "test.txt", "main", "Main change")
+	featureHead, _ := git.HeadCommit(wtPath)
+
+	_, err := runCommandForTest(runLand, "feature/x")
+	if !errors.Is(err, git.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+	if head := runGit(t, repoDir, "rev-parse", "main"); head != mainHead {
+		t.Errorf("expected main to stay at %s, got %s", mainHead, head)
+	}
+	if head, _ := git.HeadCommit(wtPath); head != featureHead {
+		t.Errorf("expected feature/x to stay at %s, got %s", featureHead, head)
+	}
+	if status := runGit(t, repoDir, "status", "--porcelain"); status != "" {
+		t.Errorf("expected merge to be aborted, got %q", status)
+	}
+}
+
+func TestLandClear(t *testing.T) {
+	repoDir, _ := setupTestRepo(t)
+	wtPath := setupLandWorktree(t, "feature.txt", "feature")
+	landSquash = true
+	landClear = true
+
+	if _, err := runCommandForTest(runLand, "feature/x"); err != nil {
+		t.Fatalf("runLand returned error: %v", err)
+	}
+	if _, err := os.Stat(wtPath); !os.IsNotExist(err) {
+		t.Errorf("expected worktree to be removed, got %v", err)
+	}
+	// スカッシュしたブランチはマージ済みと判定されないが、強制的に削除する
+	if git.BranchExists("feature/x") {
+		t.Error("expected feature/x to be deleted")
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "feature.txt")); err != nil {
+		t.Errorf("expected landed file in the main worktree: %v", err)
+	}
+	// land は clear のフラグを変更しない
+	if clearForce {
+		t.Error("expected clearForce to be left unchanged")
+	}
+}
+
+// mustFindWorktree はブランチをチェックアウトしている worktree を返す
+func mustFindWorktree(t *testing.T, branch string) git.WorktreeInfo {
+	t.Helper()
+	wt, ok := git.WorktreeForBranch(branch)
+	if !ok {
+		t.Fatalf("expected worktree for %s", branch)
+	}
+	return wt
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/ongasatoshi/scion/internal/config"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/layout"
+)
+
+// worktreePathFor は worktree.placement（dir_template 指定時はそのテンプレート）からブランチの worktree のパスを返す
+func worktreePathFor(cfg *config.Config, branchName string) (string, error) {
+	tmpl, err := layout.Template(cfg.Worktree.Placement, cfg.Worktree.DirTemplate)
+	if err != nil {
+		return "", err
+	}
+	repoRoot, err := git.GetMainWorktreeRoot()
+	if err != nil {
+		return "", err
+	}
+
+	data := layout.Data{
+		Repo:     filepath.Base(repoRoot),
+		RepoPath: repoRoot,
+		Branch:   branchName,
+		BaseDir:  cfg.Worktree.BaseDir,
+		Root:     cfg.Worktree.Root,
+		Host:     layout.LocalHost,
+	}
+	// リモートのURLは root 配置などテンプレートで使用する場合のみ取得する
+	if strings.Contains(tmpl, ".Host") || strings.Contains(tmpl, ".Owner") {
+		if url, err := git.RemoteURL(cfg.Git.DefaultRemote); err == nil {
+			data.Host, data.Owner = layout.ParseRemoteURL(url)
+		}
+	}
+	return layout.Path(tmpl, data)
+}
+
+// excludeWorktreePath は worktree をメインworktreeの中に作成する場合に、そのディレクトリを .git/info/exclude に追加する
+// 除外できなくても worktree の作成には影響しないため、警告にとどめる
+func excludeWorktreePath(p *printer, path string) {
+	repoRoot, err := git.GetMainWorktreeRoot()
+	if err != nil {
+		return
+	}
+	dir, err := git.GetCommonDir()
+	if err == nil {
+		err = layout.EnsureExcluded(dir, repoRoot, path)
+	}
+	if err != nil {
+		p.Warning(i18n.MsgLayoutExcludeFailed, err)
+	}
+}
+
+// allocateWorktreePath は新しく作成する worktree のパスを返す
+// テンプレートから求めたパスを他のブランチの worktree が使用している場合は、末尾に番号を付けたパスを返す
+func allocateWorktreePath(p *printer, cfg *config.Config, branchName string) (string, error) {
+	path, err := worktreePathFor(cfg, branchName)
+	if err != nil {
+		return "", err
+	}
+
+	owners, err := worktreeOwners()
+	if err != nil {
+		return "", err
+	}
+	unique := layout.Unique(path, func(candidate string) bool {
+		owner, ok := owners[candidate]
+		return ok && owner != branchName
+	})
+	if unique != path {
+		p.Warning(i18n.MsgLayoutCollision, path, owners[path], unique)
+	}
+	return unique, nil
+}
+
+// worktreeOwners は登録済みの worktree のパスと、その名前（ブランチ名）の対応を返す
+// detached HEAD の worktree は作成時に記録した名前を使用する
+func worktreeOwners() (map[string]string, error) {
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return nil, err
+	}
+	store, _ := openWorktreeStore()
+
+	owners := map[string]string{}
+	for _, wt := range worktrees {
+		owners[filepath.Clean(wt.Path)], _ = worktreeName(wt, store)
+	}
+	return owners, nil
+}
+
+// worktreeName は clear などで worktree を指定するときの名前と、それがブランチ名かどうかを返す
+// detached HEAD の場合は対応表に記録したブランチ名、create --detach で付けた名前、ディレクトリ名の順に使用する
+func worktreeName(wt git.WorktreeInfo, store *layout.Store) (name string, isBranch bool) {
+	if wt.Branch != "" {
+		return wt.Branch, true
+	}
+	if store != nil {
+		if branch, ok := store.Branch(wt.Path); ok {
+			return branch, true
+		}
+		if name, ok := store.DetachedName(wt.Path); ok {
+			return name, false
+		}
+	}
+	return filepath.Base(wt.Path), false
+}
+
+// openWorktreeStore はリポジトリの worktree の対応表を読み込む
+func openWorktreeStore() (*layout.Store, error) {
+	dir, err := git.GetCommonDir()
+	if err != nil {
+		return nil, err
+	}
+	return layout.OpenStore(dir)
+}
+
+// updateWorktreeStore は対応表を更新して保存する
+// 対応表はディレクトリからブランチを求めるための補助的な情報のため、失敗しても警告にとどめる
+func updateWorktreeStore(p *printer, update func(store *layout.Store)) {
+	store, err := openWorktreeStore()
+	if err == nil {
+		update(store)
+		store.Prune()
+		err = store.Save()
+	}
+	if err != nil {
+		p.Warning(i18n.MsgLayoutStoreFailed, err)
+	}
+}
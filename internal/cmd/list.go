@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var listFormat string
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   i18n.CmdListShort,
+	Long:    i18n.CmdListLong,
+	Args:    cobra.NoArgs,
+	RunE:    runList,
+}
+
+// shortHeadLength は表に表示するコミットハッシュの長さ
+const shortHeadLength = 7
+
+// worktreeItem は list コマンドで表示する worktree の情報
+// --format のテンプレートにはこの構造体が渡される
+type worktreeItem struct {
+	Branch string
+	// Name は clear などで worktree を指定する名前（detached HEAD の場合は作成時の名前かディレクトリ名）
+	Name     string
+	Path     string
+	Head     string
+	Main     bool
+	Bare     bool
+	Detached bool
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringVar(&listFormat, "format", "", i18n.FlagListFormat)
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
+	// Gitリポジトリかどうか確認
+	if !git.IsGitRepository() {
+		return git.Errorf(git.ErrNotGitRepo, i18n.MsgNotGitRepository)
+	}
+
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return err
+	}
+
+	store, _ := openWorktreeStore()
+	items := make([]worktreeItem, 0, len(worktrees))
+	for i, wt := range worktrees {
+		name, _ := worktreeName(wt, store)
+		items = append(items, worktreeItem{
+			Branch:   wt.Branch,
+			Name:     name,
+			Path:     wt.Path,
+			Head:     wt.Head,
+			Main:     i == 0,
+			Bare:     wt.IsBare,
+			Detached: wt.Detached,
+		})
+	}
+
+	if listFormat != "" {
+		tmpl, err := output.ParseFormat(listFormat)
+		if err != nil {
+			return i18n.Errorf(i18n.MsgInvalidFormat, err)
+		}
+		return output.RenderFormat(p.Stdout(), tmpl, items)
+	}
+
+	table := output.NewTable(
+		i18n.Text(i18n.MsgListHeaderBranch),
+		i18n.Text(i18n.MsgListHeaderHead),
+		i18n.Text(i18n.MsgListHeaderPath),
+	)
+	table.SetTruncatable(2)
+	for _, item := range items {
+		branch := item.Branch
+		switch {
+		case item.Bare:
+			branch = i18n.Text(i18n.MsgListBare)
+		case item.Detached:
+			branch = i18n.Text(i18n.MsgListDetached) + " " + item.Name
+		}
+
+		head := item.Head
+		if len(head) > shortHeadLength {
+			head = head[:shortHeadLength]
+		}
+		table.AddRow(branch, head, item.Path)
+	}
+	return p.RenderTable(table)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ヘルプテキストの元のメッセージIDを保持するアノテーションのキー
+const (
+	annotationShortID = "i18n.short"
+	annotationLongID  = "i18n.long"
+	annotationUsageID = "i18n.usage"
+)
+
+// localizeCommands はコマンドツリー全体のヘルプテキストとフラグの説明を現在の言語に翻訳する
+// Short/Long/Usage にはメッセージIDを設定しておき、初回の翻訳時にIDをアノテーションへ退避する
+// 言語が変わった場合も退避したIDから翻訳し直せるため、何度呼び出してもよい
+func localizeCommands(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Short = localizeText(cmd.Annotations, annotationShortID, cmd.Short)
+	cmd.Long = localizeText(cmd.Annotations, annotationLongID, cmd.Long)
+
+	localizeFlag := func(f *pflag.Flag) {
+		if f.Annotations == nil {
+			f.Annotations = map[string][]string{}
+		}
+		if _, ok := f.Annotations[annotationUsageID]; !ok {
+			f.Annotations[annotationUsageID] = []string{f.Usage}
+		}
+		f.Usage = i18n.Text(i18n.MessageID(f.Annotations[annotationUsageID][0]))
+	}
+	cmd.Flags().VisitAll(localizeFlag)
+	cmd.PersistentFlags().VisitAll(localizeFlag)
+
+	for _, sub := range cmd.Commands() {
+		localizeCommands(sub)
+	}
+}
+
+// localizeText はアノテーションに退避したメッセージIDからテキストを翻訳する
+func localizeText(annotations map[string]string, key, text string) string {
+	id, ok := annotations[key]
+	if !ok {
+		if text == "" {
+			return ""
+		}
+		id = text
+		annotations[key] = id
+	}
+	return i18n.Text(i18n.MessageID(id))
+}
+
+// applyLocale は設定の ui.language と環境変数から表示言語を決定し、ヘルプテキストに反映する
+func applyLocale(root *cobra.Command, language string) {
+	i18n.SetLocale(i18n.Detect(language))
+	localizeCommands(root)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"github.com/ongasatoshi/scion/internal/audit"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logBranch   string
+	logAction   string
+	logLimit    int
+	logAllRepos bool
+	logFormat   string
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: i18n.CmdLogShort,
+	Long:  i18n.CmdLogLong,
+	Args:  cobra.NoArgs,
+	RunE:  runLog,
+}
+
+// logTimeFormat は表に表示する日時の形式
+const logTimeFormat = "2006-01-02 15:04:05"
+
+// defaultLogLimit は表示する操作の件数のデフォルト値
+const defaultLogLimit = 20
+
+// logActions は --action に指定できる操作の種類
+var logActions = []string{audit.ActionCreate, audit.ActionClear, audit.ActionConfigSet, audit.ActionConfigReset, audit.ActionSync, audit.ActionLand}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+
+	logCmd.Flags().StringVarP(&logBranch, "branch", "b", "", i18n.FlagLogBranch)
+	logCmd.Flags().StringVar(&logAction, "action", "", i18n.FlagLogAction)
+	logCmd.Flags().IntVarP(&logLimit, "limit", "n", defaultLogLimit, i18n.FlagLogLimit)
+	logCmd.Flags().BoolVar(&logAllRepos, "all-repos", false, i18n.FlagLogAllRepos)
+	logCmd.Flags().StringVar(&logFormat, "format", "", i18n.FlagLogFormat)
+
+	logCmd.RegisterFlagCompletionFunc("branch", completeBranches)
+	logCmd.RegisterFlagCompletionFunc("action", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return filterPrefix(logActions, toComplete), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
+	path, err := audit.DefaultPath()
+	if err != nil {
+		return err
+	}
+
+	// Gitリポジトリ内ではそのリポジトリの操作だけを表示する
+	filter := audit.Filter{Branch: logBranch, Action: logAction, Limit: logLimit}
+	if !logAllRepos && git.IsGitRepository() {
+		if filter.Repo, err = git.GetMainWorktreeRoot(); err != nil {
+			return err
+		}
+	}
+
+	entries, err := audit.Read(path, filter)
+	if err != nil {
+		return i18n.Errorf(i18n.MsgLogReadFailed, err)
+	}
+
+	if logFormat != "" {
+		tmpl, err := output.ParseFormat(logFormat)
+		if err != nil {
+			return i18n.Errorf(i18n.MsgInvalidFormat, err)
+		}
+		return output.RenderFormat(p.Stdout(), tmpl, entries)
+	}
+
+	if len(entries) == 0 {
+		p.Info(i18n.MsgLogEmpty)
+		return nil
+	}
+
+	table := output.NewTable(
+		i18n.Text(i18n.MsgLogHeaderTime),
+		i18n.Text(i18n.MsgLogHeaderAction),
+		i18n.Text(i18n.MsgListHeaderBranch),
+		i18n.Text(i18n.MsgListHeaderHead),
+		i18n.Text(i18n.MsgLogHeaderOutcome),
+		i18n.Text(i18n.MsgListHeaderPath),
+	)
+	table.SetTruncatable(5)
+	for _, e := range entries {
+		commit := e.Commit()
+		if len(commit) > shortHeadLength {
+			commit = commit[:shortHeadLength]
+		}
+		table.AddRow(e.Time.Local().Format(logTimeFormat), e.Action, e.Branch, commit, e.Outcome, e.Path)
+	}
+	return p.RenderTable(table)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/picker"
+	"github.com/spf13/cobra"
+)
+
+var openCmd = &cobra.Command{
+	Use:               "open [branch-name]",
+	Short:             i18n.CmdOpenShort,
+	Long:              i18n.CmdOpenLong,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorktreeBranches(true),
+	RunE:              runOpen,
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
+	// Gitリポジトリかどうか確認
+	if !git.IsGitRepository() {
+		return git.Errorf(git.ErrNotGitRepo, i18n.MsgNotGitRepository)
+	}
+
+	wt, err := worktreeArg(args)
+	if errors.Is(err, picker.ErrCancelled) {
+		p.Info(i18n.MsgCancelled)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return openEditor(p, wt.Path)
+}
+
+// openEditor は worktree をエディタで開く
+func openEditor(p *printer, path string) error {
+	// エディタのコマンドには引数を含められる (例: "code -n")
+	fields := strings.Fields(editorCommand())
+	if len(fields) == 0 {
+		return i18n.Errorf(i18n.MsgEditorNotSet)
+	}
+
+	p.Info(i18n.MsgOpenOpening, path)
+	execCmd := exec.Command(fields[0], append(fields[1:], path)...)
+	execCmd.Dir = path
+	execCmd.Stdin = os.Stdin
+	execCmd.Stdout = os.Stdout
+	execCmd.Stderr = os.Stderr
+
+	return execCmd.Run()
+}
+
+// editorCommand は使用するエディタのコマンドを返す
+// 環境変数 EDITOR が設定されていればそれを、なければ設定の editor.command を使用する
+func editorCommand() string {
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	return GetConfig().Editor.Command
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/picker"
+)
+
+// pickWorktrees は対話的なピッカーで worktree を選択させる
+// includeMain が false の場合はメインworktreeを選択肢から除外する
+// ユーザーがキャンセルした場合は picker.ErrCancelled を返す
+func pickWorktrees(multi, includeMain bool) ([]git.WorktreeInfo, error) {
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	// detached HEAD の worktree には作成時のブランチ名を併記する
+	store, _ := openWorktreeStore()
+
+	byPath := map[string]git.WorktreeInfo{}
+	var items []picker.Item
+	for i, wt := range worktrees {
+		if wt.IsBare || (i == 0 && !includeMain) {
+			continue
+		}
+
+		label := wt.Branch
+		if wt.Detached {
+			name, _ := worktreeName(wt, store)
+			label = i18n.Text(i18n.MsgListDetached) + " " + name
+		}
+		byPath[wt.Path] = wt
+		items = append(items, picker.Item{Label: label, Detail: wt.Path, Value: wt.Path})
+	}
+	if len(items) == 0 {
+		return nil, i18n.Errorf(i18n.MsgPickerNoWorktree)
+	}
+
+	selected, err := picker.Run(items, picker.Options{
+		Prompt:  i18n.Text(i18n.MsgPickerPrompt),
+		Multi:   multi,
+		Preview: previewWorktree,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]git.WorktreeInfo, 0, len(selected))
+	for _, item := range selected {
+		result = append(result, byPath[item.Value])
+	}
+	return result, nil
+}
+
+// previewWorktree はピッカーのプレビューとして worktree の状態を返す
+func previewWorktree(item picker.Item) string {
+	status, err := git.Status(item.Value)
+	if err != nil {
+		return err.Error()
+	}
+	return status
+}
+
+// findWorktree はブランチ名（detached HEAD の場合は worktreeName の名前）に対応する worktree を返す
+// ブランチをチェックアウトしている worktree を優先し、なければ detached HEAD の worktree から探す
+func findWorktree(name string) (git.WorktreeInfo, error) {
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return git.WorktreeInfo{}, err
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == name {
+			return wt, nil
+		}
+	}
+	store, _ := openWorktreeStore()
+	for _, wt := range worktrees {
+		if !wt.Detached {
+			continue
+		}
+		if wtName, _ := worktreeName(wt, store); wtName == name {
+			return wt, nil
+		}
+	}
+	return git.WorktreeInfo{}, git.Errorf(git.ErrBranchNotFound, i18n.MsgWorktreeNotFoundForBranch, name)
+}
+
+// worktreeArg は引数で指定されたブランチの worktree を返す
+// 引数が省略された場合は、端末であればピッカーで選択させる
+func worktreeArg(args []string) (git.WorktreeInfo, error) {
+	if len(args) > 0 {
+		return findWorktree(args[0])
+	}
+	if !picker.Available() {
+		return git.WorktreeInfo{}, i18n.Errorf(i18n.MsgBranchRequired)
+	}
+
+	selected, err := pickWorktrees(false, true)
+	if err != nil {
+		return git.WorktreeInfo{}, err
+	}
+	return selected[0], nil
+}
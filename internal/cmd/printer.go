@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// printer はメッセージカタログのメッセージを現在の言語に翻訳して output.Printer に出力する
+// 出力先や出力形式、表・進捗の表示は埋め込んだ output.Printer のものをそのまま使用する
+type printer struct {
+	*output.Printer
+}
+
+// printerFor はコマンドのコンテキストに保持された Printer に出力する printer を返す
+func printerFor(cmd *cobra.Command) *printer {
+	return &printer{output.FromContext(cmd.Context())}
+}
+
+// Success は成功メッセージを出力する
+func (p *printer) Success(id i18n.MessageID, args ...interface{}) {
+	p.Printer.Success("%s", i18n.T(id, args...))
+}
+
+// Error はエラーメッセージを出力する
+func (p *printer) Error(id i18n.MessageID, args ...interface{}) {
+	p.Printer.Error("%s", i18n.T(id, args...))
+}
+
+// Warning は警告メッセージを出力する
+func (p *printer) Warning(id i18n.MessageID, args ...interface{}) {
+	p.Printer.Warning("%s", i18n.T(id, args...))
+}
+
+// Info は情報メッセージを出力する
+func (p *printer) Info(id i18n.MessageID, args ...interface{}) {
+	p.Printer.Info("%s", i18n.T(id, args...))
+}
+
+// Verbose は詳細モードでのみ情報メッセージを出力する
+func (p *printer) Verbose(id i18n.MessageID, args ...interface{}) {
+	p.Printer.Verbose("%s", i18n.T(id, args...))
+}
+
+// Debug はデバッグモードでのみメッセージを出力する
+func (p *printer) Debug(id i18n.MessageID, args ...interface{}) {
+	p.Printer.Debug("%s", i18n.T(id, args...))
+}
+
+// Print は通常のメッセージを出力する
+func (p *printer) Print(id i18n.MessageID, args ...interface{}) {
+	p.Printer.Print("%s", i18n.T(id, args...))
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/pkg/output"
+)
+
+// newTestPrinter はバッファに書き込む printer を作成する
+func newTestPrinter() (*printer, *bytes.Buffer, *bytes.Buffer) {
+	var out, errOut bytes.Buffer
+	return &printer{output.NewPrinter(&out, &errOut)}, &out, &errOut
+}
+
+// discardPrinter は出力を破棄する printer を作成する
+func discardPrinter() *printer {
+	p, _, _ := newTestPrinter()
+	return p
+}
+
+func TestPrinterTranslatesMessages(t *testing.T) {
+	original := i18n.CurrentLocale()
+	defer i18n.SetLocale(original)
+
+	p, out, errOut := newTestPrinter()
+
+	i18n.SetLocale(i18n.Japanese)
+	p.Info(i18n.MsgCancelled)
+	if !strings.Contains(out.String(), "キャンセルしました") {
+		t.Errorf("expected Japanese message, got %q", out.String())
+	}
+
+	i18n.SetLocale(i18n.English)
+	p.Warning(i18n.MsgCancelled)
+	if !strings.Contains(errOut.String(), "⚠ Cancelled") {
+		t.Errorf("expected English warning, got %q", errOut.String())
+	}
+}
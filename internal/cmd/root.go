@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/ongasatoshi/scion/internal/config"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -13,47 +20,161 @@ var (
 	// Commit はビルド時に設定される
 	Commit = "unknown"
 
-	cfgFile string
-	cfg     *config.Config
+	cfgFile     string
+	profileName string
+	noColor     bool
+	verbose     bool
+	quiet       bool
+	debug       bool
+	assumeYes   bool
+	errorFormat string
+	cfg         *config.Config
 )
 
 // rootCmd はベースコマンド
 var rootCmd = &cobra.Command{
-	Use:   "scion",
-	Short: "Git Worktreeを効率的に管理するCLIツール",
-	Long: `scionはGit Worktreeを効率的に管理するためのCLIツールです。
-AI開発環境（Claude Code、GitHub Copilot、Cursor）との連携を前提とした、
-シンプルで使いやすいワークフロー管理を提供します。
-
-利用可能なコマンド:
-  create  - 新しいworktreeブランチを作成
-  clear   - 既存のworktreeブランチを削除
-  config  - scionの設定を管理`,
+	Use:           "scion",
+	Short:         i18n.CmdRootShort,
+	Long:          i18n.CmdRootLong,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		p := printerFor(cmd)
+
+		if errorFormat != ErrorFormatText && errorFormat != ErrorFormatJSON {
+			return i18n.Errorf(i18n.MsgInvalidErrorFormat, errorFormat)
+		}
+
+		// プロファイルはフラグ、環境変数の順に決定する
+		profile := profileName
+		if profile == "" {
+			profile = os.Getenv(config.ProfileEnv)
+		}
+
 		var err error
-		cfg, err = config.Load(cfgFile)
+		cfg, err = config.Load(cfgFile, profile)
 		if err != nil {
-			return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+			return i18n.Errorf(i18n.MsgConfigLoadFailed, err)
+		}
+
+		applyLocale(cmd.Root(), cfg.UI.Language)
+		configureOutput(p.Printer, cfg)
+		for _, path := range cfg.LoadedFiles {
+			p.Verbose(i18n.MsgVerboseLoadedFile, path)
 		}
+		for _, warning := range cfg.Warnings {
+			p.Printer.Warning("%v", warning)
+		}
+		if cfg.ActiveProfile != "" {
+			p.Verbose(i18n.MsgVerboseProfile, cfg.ActiveProfile)
+		}
+		p.Verbose(i18n.MsgVerboseLocale, i18n.CurrentLocale())
 		return nil
 	},
 }
 
-// Execute はルートコマンドを実行する
+// エラーの出力形式
+const (
+	ErrorFormatText = "text"
+	ErrorFormatJSON = "json"
+)
+
+// ErrorFormat は --error-format で指定されたエラーの出力形式を返す
+func ErrorFormat() string {
+	return errorFormat
+}
+
+// Execute はルートコマンドを標準出力・標準エラー出力に出力して実行する
 func Execute() error {
-	return rootCmd.Execute()
+	return ExecuteContext(context.Background())
+}
+
+// ExecuteContext はルートコマンドを実行する
+// ctx に output.WithPrinter で Printer を設定すると、すべての出力がその Printer に書き込まれる
+func ExecuteContext(ctx context.Context) error {
+	p := output.FromContext(ctx)
+	rootCmd.SetOut(p.Stdout())
+	rootCmd.SetErr(p.Stderr())
+
+	// フラグの解析に失敗した場合のエラーも出力先の端末に合わせて表示する
+	output.SetDefault(p)
+	configureOutput(p, nil)
+	git.SetCommandTracer(traceGitCommand(&printer{p}))
+
+	// 設定ファイルの読み込み前に表示されるヘルプは環境変数の言語で表示する
+	applyLocale(rootCmd, "")
+	return rootCmd.ExecuteContext(output.WithPrinter(ctx, p))
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "設定ファイルのパス (デフォルト: ~/.config/scion/config.toml)")
-	rootCmd.Flags().BoolP("version", "v", false, "バージョン情報を表示")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", i18n.FlagRootConfig)
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", i18n.FlagRootProfile)
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, i18n.FlagRootNoColor)
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, i18n.FlagRootVerbose)
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, i18n.FlagRootQuiet)
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, i18n.FlagRootDebug)
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, i18n.FlagRootYes)
+	rootCmd.PersistentFlags().StringVar(&errorFormat, "error-format", ErrorFormatText, i18n.FlagRootErrorFormat)
+	rootCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
+	rootCmd.MarkFlagsMutuallyExclusive("verbose", "quiet")
+	rootCmd.MarkFlagsMutuallyExclusive("debug", "quiet")
+	rootCmd.Flags().BoolP("version", "v", false, i18n.FlagRootVersion)
 
 	rootCmd.SetVersionTemplate(fmt.Sprintf("scion version %s (commit: %s)\n", Version, Commit))
 	rootCmd.Version = Version
 }
 
+// isTerminal は出力先が端末に接続されているかどうかを返す
+// テストでは端末の代わりに任意の出力先を端末として扱えるよう、変数にしている
+var isTerminal = output.IsTerminal
+
+// configureOutput はフラグ、環境変数、設定ファイルから Printer のカラー出力と出力の詳細度を設定する
+// cfg が nil の場合はフラグと環境変数のみを反映する
+// カラー出力は、標準出力と標準エラー出力のそれぞれが端末に接続されているかどうかで出力先ごとに決める
+func configureOutput(p *output.Printer, cfg *config.Config) {
+	color, level := outputSettings(cfg)
+	p.Color = color && isTerminal(p.Stdout())
+	p.ErrColor = color && isTerminal(p.Stderr())
+	p.Level = level
+}
+
+// outputSettings はカラー出力を許可するかどうかと、出力の詳細度を返す
+// カラー出力は --no-color、環境変数 NO_COLOR と TERM=dumb、ui.color_output のいずれかで無効になる
+// 詳細度は --debug、--verbose、--quiet の順に優先し、いずれも指定されていなければ ui.verbose に従う
+func outputSettings(cfg *config.Config) (bool, output.Level) {
+	color := !noColor && output.ColorAllowed()
+	level := output.LevelNormal
+	if cfg != nil {
+		color = color && cfg.UI.ColorOutput
+		if cfg.UI.Verbose {
+			level = output.LevelVerbose
+		}
+	}
+
+	switch {
+	case debug:
+		level = output.LevelDebug
+	case verbose:
+		level = output.LevelVerbose
+	case quiet:
+		level = output.LevelQuiet
+	}
+	return color, level
+}
+
+// traceGitCommand は実行したgitコマンドと所要時間をデバッグ出力する関数を返す
+func traceGitCommand(p *printer) git.CommandTracer {
+	return func(args []string, elapsed time.Duration, err error) {
+		command := strings.Join(args, " ")
+		elapsed = elapsed.Round(time.Millisecond)
+		if err != nil {
+			p.Debug(i18n.MsgDebugCommandFailed, command, elapsed, err)
+			return
+		}
+		p.Debug(i18n.MsgDebugCommand, command, elapsed)
+	}
+}
+
 // GetConfig は現在の設定を返す
 func GetConfig() *config.Config {
 	return cfg
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ongasatoshi/scion/internal/config"
+	"github.com/ongasatoshi/scion/pkg/output"
+)
+
+// setOutputFlags はルートコマンドの出力に関するフラグを設定し、テストの終了時に元に戻す
+func setOutputFlags(t *testing.T, noColorFlag, verboseFlag, quietFlag, debugFlag bool) {
+	t.Helper()
+	saved := []bool{noColor, verbose, quiet, debug}
+	noColor, verbose, quiet, debug = noColorFlag, verboseFlag, quietFlag, debugFlag
+	t.Cleanup(func() {
+		noColor, verbose, quiet, debug = saved[0], saved[1], saved[2], saved[3]
+	})
+}
+
+func TestOutputSettings(t *testing.T) {
+	plain := config.DefaultConfig()
+	plain.UI.ColorOutput = false
+	verboseCfg := config.DefaultConfig()
+	verboseCfg.UI.Verbose = true
+
+	tests := []struct {
+		name                           string
+		noColor, verbose, quiet, debug bool
+		noColorEnv, term               string
+		cfg                            *config.Config
+		wantColor                      bool
+		wantLevel                      output.Level
+	}{
+		{name: "defaults", cfg: config.DefaultConfig(), wantColor: true, wantLevel: output.LevelNormal},
+		{name: "no config", wantColor: true, wantLevel: output.LevelNormal},
+		{name: "--no-color", noColor: true, cfg: config.DefaultConfig(), wantLevel: output.LevelNormal},
+		{name: "NO_COLOR", noColorEnv: "1", cfg: config.DefaultConfig(), wantLevel: output.LevelNormal},
+		{name: "TERM=dumb", term: "dumb", cfg: config.DefaultConfig(), wantLevel: output.LevelNormal},
+		{name: "ui.color_output = false", cfg: plain, wantLevel: output.LevelNormal},
+		{name: "ui.verbose", cfg: verboseCfg, wantColor: true, wantLevel: output.LevelVerbose},
+		{name: "--verbose", verbose: true, cfg: config.DefaultConfig(), wantColor: true, wantLevel: output.LevelVerbose},
+		{name: "--quiet overrides ui.verbose", quiet: true, cfg: verboseCfg, wantColor: true, wantLevel: output.LevelQuiet},
+		{name: "--debug", debug: true, cfg: config.DefaultConfig(), wantColor: true, wantLevel: output.LevelDebug},
+		{name: "--debug overrides --verbose", debug: true, verbose: true, cfg: config.DefaultConfig(), wantColor: true, wantLevel: output.LevelDebug},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setOutputFlags(t, tt.noColor, tt.verbose, tt.quiet, tt.debug)
+			t.Setenv(output.NoColorEnv, tt.noColorEnv)
+			term := tt.term
+			if term == "" {
+				term = "xterm-256color"
+			}
+			t.Setenv("TERM", term)
+
+			color, level := outputSettings(tt.cfg)
+			if color != tt.wantColor {
+				t.Errorf("expected color %v, got %v", tt.wantColor, color)
+			}
+			if level != tt.wantLevel {
+				t.Errorf("expected level %v, got %v", tt.wantLevel, level)
+			}
+		})
+	}
+}
+
+func TestConfigureOutputDecidesColorPerWriter(t *testing.T) {
+	setOutputFlags(t, false, false, false, false)
+	t.Setenv(output.NoColorEnv, "")
+	t.Setenv("TERM", "xterm-256color")
+
+	var terminal, redirected bytes.Buffer
+	savedIsTerminal := isTerminal
+	isTerminal = func(w io.Writer) bool { return w == &terminal }
+	defer func() { isTerminal = savedIsTerminal }()
+
+	// scion create 2>log のように標準エラー出力だけがリダイレクトされた場合
+	p := output.NewPrinter(&terminal, &redirected)
+	configureOutput(p, config.DefaultConfig())
+	if !p.Color || p.ErrColor {
+		t.Fatalf("expected color only on stdout, got Color=%v ErrColor=%v", p.Color, p.ErrColor)
+	}
+	p.Info("info")
+	p.Warning("warning")
+	if !strings.Contains(terminal.String(), "\033[") {
+		t.Errorf("expected colored stdout, got %q", terminal.String())
+	}
+	if strings.Contains(redirected.String(), "\033[") {
+		t.Errorf("expected redirected stderr without escape sequences, got %q", redirected.String())
+	}
+
+	// scion create | less のように標準出力だけがパイプに接続された場合
+	terminal.Reset()
+	redirected.Reset()
+	p = output.NewPrinter(&redirected, &terminal)
+	configureOutput(p, config.DefaultConfig())
+	if p.Color || !p.ErrColor {
+		t.Fatalf("expected color only on stderr, got Color=%v ErrColor=%v", p.Color, p.ErrColor)
+	}
+	p.Info("info")
+	p.Warning("warning")
+	if strings.Contains(redirected.String(), "\033[") {
+		t.Errorf("expected piped stdout without escape sequences, got %q", redirected.String())
+	}
+	if !strings.Contains(terminal.String(), "\033[") {
+		t.Errorf("expected colored stderr, got %q", terminal.String())
+	}
+}
+
+func TestConfigureOutputQuiet(t *testing.T) {
+	setOutputFlags(t, false, false, true, false)
+
+	var out, errOut bytes.Buffer
+	p := output.NewPrinter(&out, &errOut)
+	configureOutput(p, config.DefaultConfig())
+
+	p.Info("info")
+	p.Success("success")
+	p.Warning("warning")
+	if out.Len() != 0 {
+		t.Errorf("expected no informational output with --quiet, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "warning") {
+		t.Errorf("expected warnings with --quiet, got %q", errOut.String())
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/ongasatoshi/scion/internal/config"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// setupSubmodulesAndLFS は git worktree add でチェックアウトされないサブモジュールと Git LFS のファイルを、設定に従って取得する
+// worktree の作成は完了しているため、失敗しても警告にとどめる
+// 取得した結果は details（監査ログの追加情報）に記録する
+func setupSubmodulesAndLFS(p *printer, cfg *config.Config, worktreePath string, details map[string]string) {
+	if cfg.Git.Submodules && git.HasSubmodules(worktreePath) {
+		progress := p.StartProgress(i18n.Text(i18n.MsgCreateUpdatingSubmodules))
+		err := git.UpdateSubmodules(worktreePath, cfg.Git.SubmoduleReference, progress)
+		progress.Stop()
+		if err != nil {
+			p.Warning(i18n.MsgCreateSubmodulesFailed, err)
+		} else {
+			details["submodules"] = "updated"
+			p.Success(i18n.MsgCreateSubmodulesUpdated)
+		}
+	}
+
+	if !cfg.Git.LFSPull || !git.UsesLFS(worktreePath) {
+		return
+	}
+	if !git.LFSAvailable() {
+		p.Warning(i18n.MsgCreateLFSNotInstalled)
+		return
+	}
+	progress := p.StartProgress(i18n.Text(i18n.MsgCreatePullingLFS))
+	err := git.LFSPull(worktreePath, progress)
+	progress.Stop()
+	if err != nil {
+		p.Warning(i18n.MsgCreateLFSFailed, err)
+		return
+	}
+	details["lfs"] = "pulled"
+	p.Success(i18n.MsgCreateLFSPulled)
+}
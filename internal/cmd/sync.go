@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ongasatoshi/scion/internal/audit"
+	"github.com/ongasatoshi/scion/internal/config"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/layout"
+	"github.com/ongasatoshi/scion/internal/picker"
+	"github.com/ongasatoshi/scion/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncAll     bool
+	syncMode    string
+	syncNoFetch bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync [branch-name]",
+	Short: i18n.CmdSyncShort,
+	Long:  i18n.CmdSyncLong,
+	Args: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		if all {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: completeWorktreeBranches(true),
+	RunE:              runSync,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().BoolVarP(&syncAll, "all", "a", false, i18n.FlagSyncAll)
+	syncCmd.Flags().StringVar(&syncMode, "mode", "", i18n.FlagSyncMode)
+	syncCmd.Flags().BoolVar(&syncNoFetch, "no-fetch", false, i18n.FlagSyncNoFetch)
+
+	syncCmd.RegisterFlagCompletionFunc("mode", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return filterPrefix([]string{config.SyncRebase, config.SyncMerge}, toComplete), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// syncTarget は同期する worktree と、取り込むベース
+type syncTarget struct {
+	wt     git.WorktreeInfo
+	name   string
+	branch string
+	remote string
+	mode   string
+	base   string
+	// onto は実際に取り込むリビジョン（リモートにベースがあればリモート追跡ブランチ）
+	onto string
+}
+
+// syncResult は worktree ごとの同期の結果
+type syncResult struct {
+	target syncTarget
+	result string
+	// conflict はリベースやマージが衝突して中止したかどうか
+	conflict bool
+	// failed は衝突以外の理由で失敗したかどうか
+	failed bool
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
+	// Gitリポジトリかどうか確認
+	if !git.IsGitRepository() {
+		return git.Errorf(git.ErrNotGitRepo, i18n.MsgNotGitRepository)
+	}
+	if syncMode != "" {
+		if err := validateSyncMode(syncMode); err != nil {
+			return err
+		}
+	}
+
+	worktrees, err := syncWorktrees(args)
+	if errors.Is(err, picker.ErrCancelled) {
+		p.Info(i18n.MsgCancelled)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(worktrees) == 0 {
+		p.Info(i18n.MsgSyncNothing)
+		return nil
+	}
+
+	store, _ := openWorktreeStore()
+	targets := make([]syncTarget, 0, len(worktrees))
+	for _, wt := range worktrees {
+		target, err := newSyncTarget(wt, store)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target)
+	}
+
+	// 同じリモートを何度も fetch しないよう、リモートごとに一度だけ fetch する
+	if !syncNoFetch {
+		fetched := map[string]bool{}
+		for _, target := range targets {
+			if target.wt.Detached || fetched[target.remote] {
+				continue
+			}
+			fetched[target.remote] = true
+
+			progress := p.StartProgress(i18n.T(i18n.MsgSyncFetching, target.remote))
+			err := git.Fetch(target.remote, progress)
+			progress.Stop()
+			if err != nil {
+				p.Warning(i18n.MsgSyncFetchFailed, target.remote, err)
+			}
+		}
+	}
+
+	results := make([]syncResult, 0, len(targets))
+	for _, target := range targets {
+		results = append(results, syncWorktree(p, target))
+	}
+
+	table := output.NewTable(
+		i18n.Text(i18n.MsgListHeaderBranch),
+		i18n.Text(i18n.MsgSyncHeaderBase),
+		i18n.Text(i18n.MsgSyncHeaderResult),
+	)
+	table.SetTruncatable(2)
+	conflicts, failed := 0, false
+	mode := ""
+	for _, r := range results {
+		table.AddRow(r.target.name, r.target.base, r.result)
+		if r.conflict {
+			conflicts++
+			mode = r.target.mode
+		}
+		failed = failed || r.failed
+	}
+	if err := p.RenderTable(table); err != nil {
+		return err
+	}
+
+	if conflicts > 0 {
+		return git.Errorf(git.ErrConflict, i18n.MsgSyncConflicts, conflicts, mode)
+	}
+	if failed {
+		return i18n.Errorf(i18n.MsgSyncSomeFailed)
+	}
+	return nil
+}
+
+// validateSyncMode は git.sync_mode（または --mode）の値を検証する
+func validateSyncMode(mode string) error {
+	switch mode {
+	case config.SyncRebase, config.SyncMerge:
+		return nil
+	}
+	return i18n.Errorf(i18n.MsgSyncModeInvalid, mode)
+}
+
+// syncWorktrees は同期する worktree の一覧を返す
+// --all の場合はメインworktreeと bare リポジトリを除くすべての worktree、それ以外は引数（省略時はピッカー）で指定した worktree を返す
+func syncWorktrees(args []string) ([]git.WorktreeInfo, error) {
+	if !syncAll {
+		wt, err := worktreeArg(args)
+		if err != nil {
+			return nil, err
+		}
+		return []git.WorktreeInfo{wt}, nil
+	}
+
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return nil, err
+	}
+	var targets []git.WorktreeInfo
+	for i, wt := range worktrees {
+		if i == 0 || wt.IsBare {
+			continue
+		}
+		targets = append(targets, wt)
+	}
+	return targets, nil
+}
+
+// newSyncTarget は worktree のベースと取り込み方を求める
+func newSyncTarget(wt git.WorktreeInfo, store *layout.Store) (syncTarget, error) {
+	name, _ := worktreeName(wt, store)
+	target := syncTarget{wt: wt, name: name, branch: wt.Branch}
+
+	cfg, err := GetConfig().ForBranch(wt.Branch)
+	if err != nil {
+		return syncTarget{}, err
+	}
+	target.remote = cfg.Git.DefaultRemote
+	target.mode = syncMode
+	if target.mode == "" {
+		target.mode = cfg.Git.SyncMode
+		if err := validateSyncMode(target.mode); err != nil {
+			return syncTarget{}, err
+		}
+	}
+
+	target.base = worktreeBase(wt.Path, store, cfg)
+	target.onto = target.base
+	if git.RemoteBranchExists(target.remote, target.base) {
+		target.onto = target.remote + "/" + target.base
+	}
+	return target, nil
+}
+
+// worktreeBase は worktree のブランチのベースを返す
+// 作成時に記録したベースがなければ、設定（ブランチに一致するルールを適用したもの）の git.default_base_branch を返す
+func worktreeBase(path string, store *layout.Store, cfg *config.Config) string {
+	if store != nil {
+		if base, ok := store.Base(path); ok {
+			return base
+		}
+	}
+	return cfg.Git.DefaultBaseBranch
+}
+
+// syncWorktree は worktree のブランチにベースを取り込み、結果を返す
+// 未コミットの変更がある worktree と detached HEAD の worktree はスキップする
+// リベースやマージを試みた結果は、実行前後のコミットとともに監査ログに記録する
+func syncWorktree(p *printer, target syncTarget) syncResult {
+	r := syncResult{target: target}
+
+	switch {
+	case target.wt.Detached:
+		r.result = i18n.Text(i18n.MsgSyncSkippedDetached)
+		return r
+	case target.branch == target.base:
+		r.result = i18n.Text(i18n.MsgSyncSkippedSelf)
+		return r
+	}
+	hasChanges, err := git.HasUncommittedChanges(target.wt.Path)
+	if err != nil {
+		return failedSync(r, err)
+	}
+	if hasChanges {
+		r.result = i18n.Text(i18n.MsgSyncSkippedDirty)
+		return r
+	}
+
+	if git.IsAncestor(target.wt.Path, target.onto) {
+		r.result = i18n.Text(i18n.MsgSyncUpToDate)
+		return r
+	}
+
+	entry := audit.Entry{
+		Action:  audit.ActionSync,
+		Branch:  target.branch,
+		Path:    target.wt.Path,
+		Details: map[string]string{"mode": target.mode, "base": target.onto},
+	}
+	entry.Before, _ = git.HeadCommit(target.wt.Path)
+
+	progress := p.StartProgress(i18n.T(i18n.MsgSyncRunning, target.branch, target.onto))
+	if target.mode == config.SyncMerge {
+		err = git.Merge(target.wt.Path, target.onto)
+	} else {
+		err = git.Rebase(target.wt.Path, target.onto)
+	}
+	progress.Stop()
+
+	entry.After, _ = git.HeadCommit(target.wt.Path)
+	switch {
+	case errors.Is(err, git.ErrConflict):
+		r.conflict = true
+		r.result = i18n.Text(i18n.MsgSyncConflict)
+	case err != nil:
+		r = failedSync(r, err)
+	default:
+		r.result = i18n.Text(i18n.MsgSyncUpdated)
+	}
+	recordAudit(p, entry, err)
+	return r
+}
+
+// failedSync は失敗した結果を返す
+// gitのエラーメッセージは複数行になることがあるため、一覧には先頭行だけを表示する
+func failedSync(r syncResult, err error) syncResult {
+	detail, _, _ := strings.Cut(strings.TrimSpace(err.Error()), "\n")
+	r.failed = true
+	r.result = i18n.T(i18n.MsgSyncFailed, detail)
+	return r
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// runCommandForTest は printer をコンテキストに設定したコマンドで run を実行し、標準出力を返す
+func runCommandForTest(run func(*cobra.Command, []string) error, args ...string) (string, error) {
+	p, out, _ := newTestPrinter()
+	cmd := &cobra.Command{}
+	cmd.SetContext(output.WithPrinter(context.Background(), p.Printer))
+	err := run(cmd, args)
+	return out.String(), err
+}
+
+// traceFetches は実行された git fetch の回数を数える（テスト終了時に解除する）
+func traceFetches(t *testing.T) *int {
+	count := 0
+	git.SetCommandTracer(func(args []string, elapsed time.Duration, err error) {
+		if len(args) > 1 && args[1] == "fetch" {
+			count++
+		}
+	})
+	t.Cleanup(func() { git.SetCommandTracer(nil) })
+	return &count
+}
+
+// resetSyncFlags は sync のフラグをテスト終了時にデフォルト値に戻す
+func resetSyncFlags(t *testing.T) {
+	t.Cleanup(func() {
+		syncAll, syncNoFetch = false, false
+		syncMode = ""
+	})
+}
+
+// worktreePathForBranch はブランチをチェックアウトしている worktree のパスを返す
+func worktreePathForBranch(t *testing.T, branch string) string {
+	t.Helper()
+	wt, ok := git.WorktreeForBranch(branch)
+	if !ok {
+		t.Fatalf("expected worktree for %s", branch)
+	}
+	return wt.Path
+}
+
+// assertResultRow は同期結果の表に、名前と結果を含む行があることを確認する
+func assertResultRow(t *testing.T, table, name string, result i18n.MessageID) {
+	t.Helper()
+	for _, line := range strings.Split(table, "\n") {
+		if strings.Contains(line, name) && strings.Contains(line, i18n.Text(result)) {
+			return
+		}
+	}
+	t.Errorf("expected row for %s with %q, got:\n%s", name, i18n.Text(result), table)
+}
+
+func TestSyncAll(t *testing.T) {
+	repoDir, remoteDir := setupTestRepo(t)
+	resetCreateFlags(t)
+	resetSyncFlags(t)
+
+	for _, branch := range []string{"feature/a", "feature/dirty", "feature/conflict"} {
+		if err := createWorktree(discardPrinter(), branch); err != nil {
+			t.Fatalf("failed to create %s: %v", branch, err)
+		}
+	}
+	// ローカルにだけあるベースは、そのまま取り込む
+	runGit(t, repoDir, "branch", "develop")
+	createBaseBranch = "develop"
+	if err := createWorktree(discardPrinter(), "feature/develop"); err != nil {
+		t.Fatalf("failed to create feature/develop: %v", err)
+	}
+	createBaseBranch = ""
+	detached := filepath.Join(filepath.Dir(repoDir), "detached")
+	runGit(t, repoDir, "worktree", "add", "--detach", detached, "HEAD")
+
+	dirtyPath := worktreePathForBranch(t, "feature/dirty")
+	dirtyHead, _ := git.HeadCommit(dirtyPath)
+	if err := os.WriteFile(filepath.Join(dirtyPath, "test.txt"), []byte("dirty"), 0644); err != nil {
+		t.Fatalf("failed to modify worktree: %v", err)
+	}
+	conflictPath := worktreePathForBranch(t, "feature/conflict")
+	conflictHead := commitFile(t, conflictPath, "test.txt", "conflict", "Conflicting change")
+
+	// リモートの main だけを進める（ローカルの main は古いまま）
+	clone := filepath.Join(filepath.Dir(repoDir), "clone")
+	runGit(t, repoDir, "clone", remoteDir, clone)
+	runGit(t, clone, "config", "user.email", "test@example.com")
+	runGit(t, clone, "config", "user.name", "Test User")
+	upstream := commitFile(t, clone, "test.txt", "upstream", "Upstream change")
+	runGit(t, clone, "push", "origin", "main")
+	localMain := runGit(t, repoDir, "rev-parse", "main")
+
+	runGit(t, repoDir, "switch", "develop")
+	developHead := commitFile(t, repoDir, "develop.txt", "develop", "Develop change")
+	runGit(t, repoDir, "switch", "main")
+
+	fetches := traceFetches(t)
+	syncAll = true
+	table, err := runCommandForTest(runSync)
+	if !errors.Is(err, git.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+
+	// 同じリモートは一度だけ fetch する
+	if *fetches != 1 {
+		t.Errorf("expected origin to be fetched once, got %d", *fetches)
+	}
+
+	// 記録したベースがリモートにある場合はリモート追跡ブランチを取り込む
+	aPath := worktreePathForBranch(t, "feature/a")
+	if !git.IsAncestor(aPath, upstream) {
+		t.Error("expected feature/a to be rebased onto origin/main")
+	}
+	if got := runGit(t, repoDir, "rev-parse", "main"); got != localMain {
+		t.Errorf("expected local main to stay at %s, got %s", localMain, got)
+	}
+	developPath := worktreePathForBranch(t, "feature/develop")
+	if !git.IsAncestor(developPath, developHead) || git.IsAncestor(developPath, upstream) {
+		t.Error("expected feature/develop to be rebased onto its recorded base develop")
+	}
+
+	// スキップした worktree と衝突した worktree は変更しない
+	if head, _ := git.HeadCommit(dirtyPath); head != dirtyHead {
+		t.Errorf("expected dirty worktree to stay at %s, got %s", dirtyHead, head)
+	}
+	if head, _ := git.HeadCommit(conflictPath); head != conflictHead {
+		t.Errorf("expected conflicting worktree to stay at %s, got %s", conflictHead, head)
+	}
+	if changed, _ := git.HasUncommittedChanges(conflictPath); changed {
+		t.Error("expected conflicting rebase to be aborted")
+	}
+
+	assertResultRow(t, table, "feature/a", i18n.MsgSyncUpdated)
+	assertResultRow(t, table, "feature/develop", i18n.MsgSyncUpdated)
+	assertResultRow(t, table, "feature/dirty", i18n.MsgSyncSkippedDirty)
+	assertResultRow(t, table, "feature/conflict", i18n.MsgSyncConflict)
+	assertResultRow(t, table, "detached", i18n.MsgSyncSkippedDetached)
+	if !strings.Contains(table, "main") || !strings.Contains(table, "develop") {
+		t.Errorf("expected bases in the table, got:\n%s", table)
+	}
+}
+
+func TestSyncUpToDateWithoutFetch(t *testing.T) {
+	setupTestRepo(t)
+	resetCreateFlags(t)
+	resetSyncFlags(t)
+
+	if err := createWorktree(discardPrinter(), "feature/a"); err != nil {
+		t.Fatalf("failed to create feature/a: %v", err)
+	}
+
+	fetches := traceFetches(t)
+	syncNoFetch = true
+	table, err := runCommandForTest(runSync, "feature/a")
+	if err != nil {
+		t.Fatalf("runSync returned error: %v", err)
+	}
+	if *fetches != 0 {
+		t.Errorf("expected no fetch with --no-fetch, got %d", *fetches)
+	}
+	assertResultRow(t, table, "feature/a", i18n.MsgSyncUpToDate)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: i18n.CmdUIShort,
+	Long:  i18n.CmdUILong,
+	Args:  cobra.NoArgs,
+	RunE:  runUI,
+}
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+}
+
+func runUI(cmd *cobra.Command, args []string) error {
+	p := printerFor(cmd)
+
+	// Gitリポジトリかどうか確認
+	if !git.IsGitRepository() {
+		return git.Errorf(git.ErrNotGitRepo, i18n.MsgNotGitRepository)
+	}
+
+	repoRoot, err := git.GetRepositoryRoot()
+	if err != nil {
+		return err
+	}
+
+	config := GetConfig()
+	err = tui.Run(tui.Options{
+		Title:  i18n.T(i18n.MsgUITitle, repoRoot),
+		Agents: config.Agent.Processes,
+		Create: func(branch string) error {
+			return createWorktree(p, branch)
+		},
+		Clear: func(wt git.WorktreeInfo) error {
+			store, _ := openWorktreeStore()
+			return clearListedWorktree(p, wt, store, false)
+		},
+		Agent: func(wt git.WorktreeInfo) error {
+			return runAgent(wt.Path)
+		},
+		Editor: func(wt git.WorktreeInfo) error {
+			return openEditor(p, wt.Path)
+		},
+	})
+	if errors.Is(err, tui.ErrNotTerminal) {
+		return i18n.Errorf(i18n.MsgUINotTerminal)
+	}
+	return err
+}
+
+// runAgent は worktree で設定の agent.command を実行する
+func runAgent(path string) error {
+	// エージェントのコマンドには引数を含められる (例: "claude --continue")
+	fields := strings.Fields(GetConfig().Agent.Command)
+	if len(fields) == 0 {
+		return i18n.Errorf(i18n.MsgUIAgentNotSet)
+	}
+
+	execCmd := exec.Command(fields[0], fields[1:]...)
+	execCmd.Dir = path
+	execCmd.Stdin = os.Stdin
+	execCmd.Stdout = os.Stdout
+	execCmd.Stderr = os.Stderr
+
+	return execCmd.Run()
+}
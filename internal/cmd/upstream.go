@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"github.com/ongasatoshi/scion/internal/config"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// validateUpstreamMode は git.upstream_mode の値を検証する
+func validateUpstreamMode(mode string) error {
+	switch mode {
+	case config.UpstreamNone, config.UpstreamPush, config.UpstreamBase:
+		return nil
+	}
+	return i18n.Errorf(i18n.MsgCreateUpstreamModeInvalid, mode)
+}
+
+// setupUpstream は新しく作成したブランチの上流を git.upstream_mode に従って設定し、push が true の場合はリモートに push する
+// worktree の作成は完了しているため、失敗しても警告にとどめる
+// 設定した上流と push の結果は details（監査ログの追加情報）に記録する
+func setupUpstream(p *printer, mode, worktreePath, branchName, baseBranch, remote string, push bool, details map[string]string) {
+	switch mode {
+	case config.UpstreamPush:
+		if !push {
+			// まだリモートにブランチがなくても、後から git push するだけで同名のブランチに push できるようにする
+			setUpstream(p, branchName, remote, branchName, details)
+		}
+	case config.UpstreamBase:
+		if upstreamRemote, upstreamBranch, ok := baseUpstream(remote, baseBranch); ok {
+			setUpstream(p, branchName, upstreamRemote, upstreamBranch, details)
+		} else {
+			p.Warning(i18n.MsgCreateUpstreamNoBase, baseBranch)
+		}
+	}
+
+	if !push {
+		return
+	}
+	// upstream_mode = base の場合は、push 先ではなくベースブランチを上流のままにする
+	setUpstreamOnPush := mode != config.UpstreamBase
+
+	progress := p.StartProgress(i18n.T(i18n.MsgCreatePushing, branchName, remote))
+	err := git.Push(worktreePath, remote, branchName, setUpstreamOnPush, progress)
+	progress.Stop()
+	if err != nil {
+		p.Warning(i18n.MsgCreatePushFailed, err)
+		return
+	}
+	details["pushed"] = remote
+	p.Success(i18n.MsgCreatePushed, branchName, remote)
+	if setUpstreamOnPush {
+		details["upstream"] = remote + "/" + branchName
+		p.Success(i18n.MsgCreateUpstreamSet, remote+"/"+branchName)
+	}
+}
+
+// setUpstream はブランチの上流を設定し、結果を表示する
+func setUpstream(p *printer, branchName, remote, upstreamBranch string, details map[string]string) {
+	if err := git.SetUpstream(branchName, remote, upstreamBranch); err != nil {
+		p.Warning(i18n.MsgCreateUpstreamFailed, err)
+		return
+	}
+	upstream := upstreamBranch
+	if remote != "." {
+		upstream = remote + "/" + upstreamBranch
+	}
+	details["upstream"] = upstream
+	p.Success(i18n.MsgCreateUpstreamSet, upstream)
+}
+
+// baseUpstream はベースブランチを上流にする場合のリモートとブランチ名を返す
+// リモートに同名のブランチがあればそれを、なければローカルのブランチ（リモート "."）を使用する
+// ベースがタグやコミットの場合は ok に false を返す
+func baseUpstream(remote, baseBranch string) (upstreamRemote, upstreamBranch string, ok bool) {
+	if git.RemoteBranchExists(remote, baseBranch) {
+		return remote, baseBranch, true
+	}
+	if r, b, found := git.SplitRemoteBranch(baseBranch); found && git.RemoteBranchExists(r, b) {
+		return r, b, true
+	}
+	if git.BranchExists(baseBranch) {
+		return ".", baseBranch, true
+	}
+	return "", "", false
+}
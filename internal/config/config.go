@@ -1,62 +1,122 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/layout"
 	"github.com/pelletier/go-toml/v2"
 )
 
 // Config はscionの設定構造体
 type Config struct {
-	Repository RepositoryConfig `toml:"repository"`
-	Worktree   WorktreeConfig   `toml:"worktree"`
-	Git        GitConfig        `toml:"git"`
-	UI         UIConfig         `toml:"ui"`
-	Editor     EditorConfig     `toml:"editor"`
+	Version    int                `toml:"version" comment:"設定ファイルのバージョン (scion config migrate で更新)"`
+	Repository RepositoryConfig   `toml:"repository" comment:"リポジトリ関連の設定"`
+	Worktree   WorktreeConfig     `toml:"worktree" comment:"Worktree関連の設定"`
+	Git        GitConfig          `toml:"git" comment:"Git関連の設定"`
+	UI         UIConfig           `toml:"ui" comment:"UI関連の設定"`
+	Editor     EditorConfig       `toml:"editor" comment:"エディタ設定"`
+	Agent      AgentConfig        `toml:"agent" comment:"AIエージェント設定"`
+	Hooks      HooksConfig        `toml:"hooks" comment:"フック設定"`
+	Rules      []Rule             `toml:"rules,omitempty" comment:"ブランチ名のパターンごとの設定 (定義順に適用)"`
+	Profiles   map[string]Profile `toml:"profiles,omitempty" comment:"名前付きプロファイル (--profile または SCION_PROFILE で選択)"`
+
+	// ActiveProfile は適用中のプロファイル名（設定ファイルには保存しない）
+	ActiveProfile string `toml:"-"`
+	// LoadedFiles は読み込んだ設定ファイルのパス（設定ファイルには保存しない）
+	LoadedFiles []string `toml:"-"`
+	// Warnings は設定ファイルの読み込み中に見つかった、読み込みを中止するほどではない問題（設定ファイルには保存しない）
+	Warnings []error `toml:"-"`
 }
 
 // RepositoryConfig はリポジトリ関連の設定
 type RepositoryConfig struct {
-	BaseRepository string `toml:"base_repository"`
-	BaseBranch     string `toml:"base_branch"`
+	BaseRepository string `toml:"base_repository" comment:"コマンド対象のリポジトリパス"`
 }
 
 // WorktreeConfig はworktree関連の設定
 type WorktreeConfig struct {
-	BaseDir                string `toml:"base_dir"`
-	AutoCreateDir          bool   `toml:"auto_create_dir"`
-	CleanupOnBranchDelete  bool   `toml:"cleanup_on_branch_delete"`
+	Placement             string   `toml:"placement" enum:"sibling,per_repo,inside,root" comment:"worktreeの配置方法 (sibling: リポジトリと同じ階層, per_repo: base_dir/リポジトリ名, inside: リポジトリ内, root: root/ホスト/所有者/リポジトリ名)"`
+	BaseDir               string   `toml:"base_dir" comment:"worktreeディレクトリのベース名"`
+	Root                  string   `toml:"root" comment:"placement = root の場合に worktree を配置するディレクトリ"`
+	DirTemplate           string   `toml:"dir_template" comment:"worktreeのパスのテンプレート (指定時は placement より優先、相対パスはリポジトリの親ディレクトリ基準、関数: slug, escape, lower)"`
+	AutoCreateDir         bool     `toml:"auto_create_dir" comment:"wtreeディレクトリを自動作成"`
+	CleanupOnBranchDelete bool     `toml:"cleanup_on_branch_delete" comment:"ブランチ削除時にworktreeも削除"`
+	SparsePaths           []string `toml:"sparse_paths" comment:"指定時は cone モードの sparse checkout でこのディレクトリだけをチェックアウト (モノレポ向け)"`
 }
 
 // GitConfig はGit関連の設定
 type GitConfig struct {
-	DefaultRemote     string `toml:"default_remote"`
-	DefaultBaseBranch string `toml:"default_base_branch"`
-	FetchBeforeCreate bool   `toml:"fetch_before_create"`
+	DefaultRemote      string `toml:"default_remote" comment:"デフォルトのリモート名"`
+	DefaultBaseBranch  string `toml:"default_base_branch" comment:"デフォルトのベースブランチ"`
+	FetchBeforeCreate  bool   `toml:"fetch_before_create" comment:"worktree作成前にfetchを実行"`
+	PushOnCreate       bool   `toml:"push_on_create" comment:"作成した新しいブランチをリモートにpushし、上流に設定"`
+	Submodules         bool   `toml:"submodules" comment:"worktree作成後にサブモジュールを初期化・更新 (.gitmodules がある場合)"`
+	SubmoduleReference bool   `toml:"submodule_reference" comment:"メインworktreeで初期化済みのサブモジュールを --reference に指定して再ダウンロードを避ける"`
+	LFSPull            bool   `toml:"lfs_pull" comment:"worktree作成後に git lfs pull を実行 (.gitattributes で LFS を使用している場合)"`
+	UpstreamMode       string `toml:"upstream_mode" enum:"none,push,base" comment:"新しいブランチの上流 (none: 設定しない, push: リモートの同名ブランチ, base: ベースブランチ)"`
+	SyncMode           string `toml:"sync_mode" enum:"rebase,merge" comment:"scion sync でベースを取り込む方法 (rebase: リベース, merge: マージ)"`
+	PullRequestRef     string `toml:"pull_request_ref" comment:"scion create --pr で取得するリモートのref (* はプルリクエストの番号、GitLab: refs/merge-requests/*/head)"`
 }
 
+// git.upstream_mode の値
+const (
+	// UpstreamNone は上流を設定しない（push_on_create で push した場合は push 先が上流になる）
+	UpstreamNone = "none"
+	// UpstreamPush はリモートの同名ブランチを上流に設定する（まだ push していなくても設定する）
+	UpstreamPush = "push"
+	// UpstreamBase はベースブランチを上流に設定する
+	UpstreamBase = "base"
+)
+
+// git.sync_mode の値
+const (
+	// SyncRebase はブランチをベースにリベースする
+	SyncRebase = "rebase"
+	// SyncMerge はベースをブランチにマージする
+	SyncMerge = "merge"
+)
+
 // UIConfig はUI関連の設定
 type UIConfig struct {
-	ColorOutput        bool `toml:"color_output"`
-	Verbose            bool `toml:"verbose"`
-	ConfirmDestructive bool `toml:"confirm_destructive"`
+	ColorOutput        bool   `toml:"color_output" comment:"カラー出力を有効化"`
+	Verbose            bool   `toml:"verbose" comment:"詳細な出力"`
+	ConfirmDestructive bool   `toml:"confirm_destructive" comment:"破壊的操作の確認"`
+	Language           string `toml:"language" enum:"auto,en,ja" comment:"表示言語 (auto: 環境変数 LC_ALL/LC_MESSAGES/LANG から判定)"`
+	PromptTimeout      int    `toml:"prompt_timeout" comment:"確認プロンプトの応答を待つ秒数 (0: 無制限)"`
 }
 
 // EditorConfig はエディタ設定
 type EditorConfig struct {
-	Command string `toml:"command"`
+	Command string `toml:"command" comment:"デフォルトエディタ"`
+}
+
+// AgentConfig はAIエージェントの設定
+type AgentConfig struct {
+	Command   string   `toml:"command" comment:"scion ui から起動するエージェントのコマンド"`
+	Processes []string `toml:"processes" comment:"実行中のエージェントとして検出するプロセス名"`
+}
+
+// HooksConfig はworktreeの作成・削除・取り込み時に実行するコマンドの設定
+type HooksConfig struct {
+	PostCreate []string `toml:"post_create" comment:"worktree作成後にworktree内で実行するコマンド"`
+	PreClear   []string `toml:"pre_clear" comment:"worktree削除前にworktree内で実行するコマンド"`
+	PreLand    []string `toml:"pre_land" comment:"scion land で取り込む前にworktree内で実行するコマンド (テストなど)"`
 }
 
 // DefaultConfig はデフォルト設定を返す
 func DefaultConfig() *Config {
 	return &Config{
+		Version: CurrentVersion,
 		Repository: RepositoryConfig{
 			BaseRepository: "",
-			BaseBranch:     "main",
 		},
 		Worktree: WorktreeConfig{
+			Placement:             layout.PlacementSibling,
 			BaseDir:               "wtree",
+			Root:                  "~/worktrees",
 			AutoCreateDir:         true,
 			CleanupOnBranchDelete: true,
 		},
@@ -64,15 +124,23 @@ func DefaultConfig() *Config {
 			DefaultRemote:     "origin",
 			DefaultBaseBranch: "main",
 			FetchBeforeCreate: true,
+			UpstreamMode:      UpstreamNone,
+			SyncMode:          SyncRebase,
+			PullRequestRef:    "refs/pull/*/head",
 		},
 		UI: UIConfig{
 			ColorOutput:        true,
 			Verbose:            false,
 			ConfirmDestructive: true,
+			Language:           "auto",
 		},
 		Editor: EditorConfig{
 			Command: "vi",
 		},
+		Agent: AgentConfig{
+			Command:   "claude",
+			Processes: []string{"claude", "codex", "aider", "gemini", "cursor-agent"},
+		},
 	}
 }
 
@@ -91,7 +159,8 @@ func LocalConfigPath() string {
 }
 
 // Load は設定ファイルを読み込む
-func Load(customPath string) (*Config, error) {
+// profile が指定された場合は、すべての設定ファイルを重ねた結果にそのプロファイルを適用する
+func Load(customPath, profile string) (*Config, error) {
 	cfg := DefaultConfig()
 
 	// グローバル設定の読み込み
@@ -115,16 +184,47 @@ func Load(customPath string) (*Config, error) {
 		}
 	}
 
+	// プロファイルの適用
+	if profile != "" {
+		if err := cfg.ApplyProfile(profile); err != nil {
+			return cfg, err
+		}
+	}
+
 	return cfg, nil
 }
 
 // loadFromFile はファイルから設定を読み込む
+// 古いバージョンの設定ファイルはメモリ上でマイグレーションしてから読み込み、
+// 変更が必要なキーが含まれていた場合は cfg.Warnings に警告を追加する
 func loadFromFile(path string, cfg *Config) error {
-	data, err := os.ReadFile(path)
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+
+	result, err := migrateDocument(doc)
+	if err != nil {
+		if result == nil || result.FromVersion <= CurrentVersion {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		// 新しいバージョンのファイルは認識できるキーのみ読み込む
+		cfg.Warnings = append(cfg.Warnings, i18n.Errorf(i18n.MsgConfigNewerVersionLoad, path, err))
+	} else if len(result.Applied) > 0 {
+		cfg.Warnings = append(cfg.Warnings, i18n.Errorf(i18n.MsgConfigOutdated, path, result.FromVersion, result.ToVersion))
+	}
+
+	data, err := toml.Marshal(doc)
 	if err != nil {
 		return err
 	}
-	return toml.Unmarshal(data, cfg)
+	if err := toml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	// ファイル側のバージョンに関わらず、読み込んだ設定は現在の形式として扱う
+	cfg.Version = CurrentVersion
+	cfg.LoadedFiles = append(cfg.LoadedFiles, path)
+	return nil
 }
 
 // Save は設定をファイルに保存する
@@ -21,6 +21,16 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("expected DefaultBaseBranch to be 'main', got '%s'", cfg.Git.DefaultBaseBranch)
 	}
 
+	// 空の場合は layout.DefaultTemplate を使用する。設定ファイルに既定値を書き込まないよう空にしておく
+	if cfg.Worktree.DirTemplate != "" {
+		t.Errorf("expected DirTemplate to be empty, got '%s'", cfg.Worktree.DirTemplate)
+	}
+
+	// サブモジュールと Git LFS の取得は時間がかかるため、設定した場合のみ行う
+	if cfg.Git.Submodules || cfg.Git.SubmoduleReference || cfg.Git.LFSPull {
+		t.Error("expected submodules, submodule_reference and lfs_pull to be disabled by default")
+	}
+
 	if !cfg.Worktree.AutoCreateDir {
 		t.Error("expected AutoCreateDir to be true")
 	}
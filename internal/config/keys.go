@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Key は設定キーの情報
+type Key struct {
+	// Name はドット区切りのキー (例: git.default_remote)
+	Name string
+	// Description は現在の言語に翻訳した説明
+	Description string
+	// Type はJSON Schemaの型名 (string, boolean, integer, array, object など)
+	Type string
+	// Enum は指定できる値（列挙値がない場合は nil）
+	Enum []string
+	// Default はデフォルト値（ない場合は nil）
+	Default interface{}
+}
+
+// Keys はConfig構造体から導出した設定キーの一覧を名前順に返す
+// セクションはその配下のキーに展開し、リスト・マップ（rules、profiles など）は1つのキーとして扱う
+func Keys() []Key {
+	var keys []Key
+	collectKeys(GenerateSchema(), "", &keys)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
+
+// collectKeys はスキーマのプロパティを末端までたどり、キーを追加する
+func collectKeys(s *Schema, prefix string, keys *[]Key) {
+	for name, prop := range s.Properties {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		if prop.Type == "object" && prop.Properties != nil {
+			collectKeys(prop, key, keys)
+			continue
+		}
+		*keys = append(*keys, Key{
+			Name:        key,
+			Description: prop.Description,
+			Type:        prop.Type,
+			Enum:        prop.Enum,
+			Default:     prop.Default,
+		})
+	}
+}
+
+// ValueHints は config set で入力する値の候補を返す
+// 列挙値、真偽値、デフォルト値の順に判定する
+func (k Key) ValueHints() []string {
+	switch {
+	case len(k.Enum) > 0:
+		return k.Enum
+	case k.Type == "boolean":
+		return []string{"true", "false"}
+	case k.Default != nil && k.Type != "array" && k.Type != "object":
+		return []string{fmt.Sprint(k.Default)}
+	}
+	return nil
+}
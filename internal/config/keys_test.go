@@ -0,0 +1,52 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+	keys := map[string]Key{}
+	for _, key := range Keys() {
+		keys[key.Name] = key
+	}
+
+	for _, name := range []string{"version", "git.default_remote", "ui.language", "hooks.post_create", "rules", "profiles"} {
+		if _, ok := keys[name]; !ok {
+			t.Errorf("expected key %q", name)
+		}
+	}
+	// セクションは配下のキーに展開される
+	for _, name := range []string{"git", "ui", "active_profile"} {
+		if _, ok := keys[name]; ok {
+			t.Errorf("unexpected key %q", name)
+		}
+	}
+
+	if key := keys["git.default_remote"]; key.Description == "" {
+		t.Error("expected git.default_remote to have a description")
+	}
+}
+
+func TestValueHints(t *testing.T) {
+	keys := map[string]Key{}
+	for _, key := range Keys() {
+		keys[key.Name] = key
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"ui.language", []string{"auto", "en", "ja"}},
+		{"ui.verbose", []string{"true", "false"}},
+		{"git.default_remote", []string{"origin"}},
+		{"hooks.post_create", nil},
+	}
+
+	for _, tt := range tests {
+		if got := keys[tt.name].ValueHints(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ValueHints(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
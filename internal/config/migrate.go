@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// CurrentVersion は現在の設定ファイルのバージョン
+// 設定キーの変更を伴う場合はバージョンを上げ、migrations にマイグレーションを追加する
+const CurrentVersion = 1
+
+// Migration は設定ファイルを1つ上のバージョンに更新する処理
+type Migration struct {
+	// From は適用対象のバージョン（適用後は From+1 になる）
+	From int
+	// Description は変更内容の説明（i18n のメッセージID）
+	Description string
+	// Apply はデコード済みの設定ドキュメントを更新し、内容を変更したかどうかを返す
+	Apply func(doc map[string]interface{}) (bool, error)
+}
+
+// migrations はバージョン順に並んだマイグレーションの一覧
+var migrations = []Migration{
+	{
+		From:        0,
+		Description: i18n.MsgMigrationBaseBranch,
+		Apply:       migrateBaseBranch,
+	},
+}
+
+// MigrationResult はマイグレーションの結果を保持する
+type MigrationResult struct {
+	Path        string
+	FromVersion int
+	ToVersion   int
+	// Applied は内容の変更を伴ったマイグレーションの説明
+	Applied []string
+}
+
+// Migrated はマイグレーションが適用されたかどうかを返す
+func (r *MigrationResult) Migrated() bool {
+	return r.FromVersion != r.ToVersion
+}
+
+// documentVersion は設定ドキュメントのバージョンを返す
+// version キーがない場合はバージョン0とみなす
+func documentVersion(doc map[string]interface{}) (int, error) {
+	raw, ok := doc["version"]
+	if !ok {
+		return 0, nil
+	}
+	switch v := raw.(type) {
+	case int64:
+		return int(v), nil
+	case int:
+		return v, nil
+	default:
+		return 0, i18n.Errorf(i18n.MsgInvalidVersion, raw)
+	}
+}
+
+// migrateDocument は設定ドキュメントを CurrentVersion まで更新する
+func migrateDocument(doc map[string]interface{}) (*MigrationResult, error) {
+	version, err := documentVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrationResult{FromVersion: version, ToVersion: version}
+	if version > CurrentVersion {
+		return result, i18n.Errorf(i18n.MsgNewerVersion, version, CurrentVersion)
+	}
+
+	for _, m := range migrations {
+		if m.From != result.ToVersion {
+			continue
+		}
+		changed, err := m.Apply(doc)
+		if err != nil {
+			return result, i18n.Errorf(i18n.MsgMigrationFailed, m.From, m.From+1, err)
+		}
+		result.ToVersion = m.From + 1
+		if changed {
+			result.Applied = append(result.Applied, m.Description)
+		}
+	}
+
+	if result.ToVersion != CurrentVersion {
+		return result, i18n.Errorf(i18n.MsgMigrationMissing, result.ToVersion)
+	}
+	doc["version"] = int64(CurrentVersion)
+	return result, nil
+}
+
+// readDocument は設定ファイルを汎用のドキュメントとして読み込む
+func readDocument(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc := make(map[string]interface{})
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// MigrateFile は設定ファイルを CurrentVersion に更新して書き戻す
+// 書き戻す前に元のファイルを <path>.bak として保存する。dryRun が true の場合はファイルを変更しない
+func MigrateFile(path string, dryRun bool) (*MigrationResult, error) {
+	doc, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := migrateDocument(doc)
+	if err != nil {
+		return result, err
+	}
+	result.Path = path
+
+	if dryRun || !result.Migrated() {
+		return result, nil
+	}
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return result, err
+	}
+	if err := os.WriteFile(path+".bak", original, 0644); err != nil {
+		return result, i18n.Errorf(i18n.MsgBackupFailed, err)
+	}
+
+	data, err := toml.Marshal(doc)
+	if err != nil {
+		return result, err
+	}
+	return result, os.WriteFile(path, data, 0644)
+}
+
+// subTable は doc 内のテーブルを返す。create が true の場合は存在しなければ作成する
+func subTable(doc map[string]interface{}, name string, create bool) map[string]interface{} {
+	if table, ok := doc[name].(map[string]interface{}); ok {
+		return table
+	}
+	if !create {
+		return nil
+	}
+	table := make(map[string]interface{})
+	doc[name] = table
+	return table
+}
+
+// migrateBaseBranch は v0 → v1 のマイグレーション
+// repository.base_branch は git.default_base_branch と重複していたため、後者に統合する
+// 両方が設定されている場合は、実際に使われていた git.default_base_branch を優先する
+func migrateBaseBranch(doc map[string]interface{}) (bool, error) {
+	repo := subTable(doc, "repository", false)
+	if repo == nil {
+		return false, nil
+	}
+	value, ok := repo["base_branch"]
+	if !ok {
+		return false, nil
+	}
+
+	gitTable := subTable(doc, "git", true)
+	if _, exists := gitTable["default_base_branch"]; !exists {
+		gitTable["default_base_branch"] = value
+	}
+	delete(repo, "base_branch")
+	if len(repo) == 0 {
+		delete(doc, "repository")
+	}
+	return true, nil
+}
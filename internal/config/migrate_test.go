@@ -0,0 +1,178 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateDocumentBaseBranch(t *testing.T) {
+	doc := map[string]interface{}{
+		"repository": map[string]interface{}{
+			"base_branch": "develop",
+		},
+	}
+
+	result, err := migrateDocument(doc)
+	if err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	if result.FromVersion != 0 || result.ToVersion != CurrentVersion {
+		t.Errorf("expected v0 → v%d, got v%d → v%d", CurrentVersion, result.FromVersion, result.ToVersion)
+	}
+
+	gitTable := subTable(doc, "git", false)
+	if gitTable == nil || gitTable["default_base_branch"] != "develop" {
+		t.Errorf("expected git.default_base_branch to be 'develop', got %v", gitTable)
+	}
+
+	if _, ok := doc["repository"]; ok {
+		t.Error("expected empty repository table to be removed")
+	}
+}
+
+func TestMigrateDocumentKeepsExistingDefaultBaseBranch(t *testing.T) {
+	doc := map[string]interface{}{
+		"repository": map[string]interface{}{
+			"base_repository": "/src/app",
+			"base_branch":     "main",
+		},
+		"git": map[string]interface{}{
+			"default_base_branch": "develop",
+		},
+	}
+
+	if _, err := migrateDocument(doc); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	if subTable(doc, "git", false)["default_base_branch"] != "develop" {
+		t.Error("expected existing git.default_base_branch to be kept")
+	}
+
+	repo := subTable(doc, "repository", false)
+	if _, ok := repo["base_branch"]; ok {
+		t.Error("expected repository.base_branch to be removed")
+	}
+	if repo["base_repository"] != "/src/app" {
+		t.Error("expected other repository keys to be kept")
+	}
+}
+
+func TestMigrateDocumentNewerVersion(t *testing.T) {
+	doc := map[string]interface{}{"version": int64(CurrentVersion + 1)}
+
+	if _, err := migrateDocument(doc); err == nil {
+		t.Error("expected error for newer config version")
+	}
+}
+
+func TestLoadFromFileMigratesOldConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := "[repository]\nbase_branch = \"develop\"\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	if err := loadFromFile(configPath, cfg); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.Git.DefaultBaseBranch != "develop" {
+		t.Errorf("expected DefaultBaseBranch to be 'develop', got '%s'", cfg.Git.DefaultBaseBranch)
+	}
+
+	// 読み込みだけではファイルは変更されない
+	data, _ := os.ReadFile(configPath)
+	if string(data) != content {
+		t.Error("expected config file to be unchanged after load")
+	}
+
+	// 警告は表示せず、呼び出し元に返す
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0].Error(), configPath) {
+		t.Errorf("expected one warning about %s, got %v", configPath, cfg.Warnings)
+	}
+	if len(cfg.LoadedFiles) != 1 || cfg.LoadedFiles[0] != configPath {
+		t.Errorf("expected loaded files to be [%s], got %v", configPath, cfg.LoadedFiles)
+	}
+}
+
+func TestMigrateDocumentWithoutDeprecatedKeys(t *testing.T) {
+	// version のない設定でも、変更が必要なキーがなければ適用したマイグレーションはない
+	doc := map[string]interface{}{
+		"worktree": map[string]interface{}{
+			"base_dir": "trees",
+		},
+	}
+
+	result, err := migrateDocument(doc)
+	if err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	if result.ToVersion != CurrentVersion {
+		t.Errorf("expected v%d, got v%d", CurrentVersion, result.ToVersion)
+	}
+	if len(result.Applied) != 0 {
+		t.Errorf("expected no applied migrations, got %v", result.Applied)
+	}
+}
+
+func TestMigrateFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := "[repository]\nbase_branch = \"develop\"\n\n[worktree]\nbase_dir = \"trees\"\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// dry-run ではファイルを変更しない
+	result, err := MigrateFile(configPath, true)
+	if err != nil {
+		t.Fatalf("failed to dry-run migrate: %v", err)
+	}
+	if !result.Migrated() {
+		t.Error("expected migration to be pending")
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != content {
+		t.Error("expected dry-run to leave the file unchanged")
+	}
+
+	if _, err := MigrateFile(configPath, false); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	backup, err := os.ReadFile(configPath + ".bak")
+	if err != nil || string(backup) != content {
+		t.Error("expected backup with original content")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read migrated config: %v", err)
+	}
+	migrated := string(data)
+	if strings.Contains(migrated, "\nbase_branch =") {
+		t.Errorf("expected base_branch to be removed, got:\n%s", migrated)
+	}
+	if !strings.Contains(migrated, "default_base_branch = 'develop'") {
+		t.Errorf("expected default_base_branch to be set, got:\n%s", migrated)
+	}
+	if !strings.Contains(migrated, "base_dir = 'trees'") {
+		t.Errorf("expected other keys to be kept, got:\n%s", migrated)
+	}
+
+	// 2回目は何もしない
+	result, err = MigrateFile(configPath, false)
+	if err != nil {
+		t.Fatalf("failed to migrate again: %v", err)
+	}
+	if result.Migrated() {
+		t.Error("expected migrated file to be up to date")
+	}
+}
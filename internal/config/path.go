@@ -0,0 +1,317 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// pathSegment は設定キーのパス要素を表す
+// ドット区切りの要素は name、角括弧の要素は bracket に格納される
+type pathSegment struct {
+	name    string
+	bracket bool
+}
+
+// appendIndex はスライスへの追加を表すインデックス表記
+const appendIndex = "+"
+
+// parsePath はドット区切りのキーをパス要素に分解する
+// 例: hooks.post_create[0], hooks.post_create[+], agents.claude.command, agents["my.agent"]
+func parsePath(key string) ([]pathSegment, error) {
+	if key == "" {
+		return nil, i18n.Errorf(i18n.MsgKeyEmpty)
+	}
+
+	var segs []pathSegment
+	rest := key
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, i18n.Errorf(i18n.MsgKeyMissingBracket, key)
+			}
+			inner := strings.Trim(rest[1:end], `"'`)
+			if inner == "" {
+				return nil, i18n.Errorf(i18n.MsgKeyEmptyIndex, key)
+			}
+			segs = append(segs, pathSegment{name: inner, bracket: true})
+			rest = rest[end+1:]
+			if strings.HasPrefix(rest, ".") {
+				rest = rest[1:]
+				if rest == "" {
+					return nil, i18n.Errorf(i18n.MsgKeyInvalid, key)
+				}
+			}
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, i18n.Errorf(i18n.MsgKeyInvalid, key)
+			}
+			segs = append(segs, pathSegment{name: rest[:end]})
+			rest = rest[end:]
+			if strings.HasPrefix(rest, ".") {
+				rest = rest[1:]
+				if rest == "" {
+					return nil, i18n.Errorf(i18n.MsgKeyInvalid, key)
+				}
+			}
+		}
+	}
+	return segs, nil
+}
+
+// GetValue はドット区切りのキーに対応する設定値を文字列として返す
+func GetValue(cfg *Config, key string) (string, error) {
+	segs, err := parsePath(key)
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+	err = walkPath(reflect.ValueOf(cfg).Elem(), segs, false, func(v reflect.Value) error {
+		result = FormatValue(v)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// SetValue はドット区切りのキーに対応する設定値を文字列から変換して設定する
+// スライスへの追加は [+]、マップの要素は存在しない場合に作成される
+func SetValue(cfg *Config, key, value string) error {
+	segs, err := parsePath(key)
+	if err != nil {
+		return err
+	}
+
+	// プロファイル内のキーはConfigの型に従って設定する
+	if len(segs) > 2 && !segs[0].bracket && segs[0].name == "profiles" {
+		return setProfileValue(cfg, segs[1].name, segs[2:], value)
+	}
+
+	return walkPath(reflect.ValueOf(cfg).Elem(), segs, true, func(v reflect.Value) error {
+		return parseValue(v, value)
+	})
+}
+
+// walkPath はパス要素をたどり、末端の値に対して fn を呼び出す
+// create が true の場合は値の変更を前提とし、マップ要素・ポインタ・スライス要素を必要に応じて作成する
+func walkPath(v reflect.Value, segs []pathSegment, create bool, fn func(reflect.Value) error) error {
+	if len(segs) == 0 {
+		return fn(v)
+	}
+
+	seg := segs[0]
+
+	switch v.Kind() {
+	case reflect.Interface:
+		// プロファイルなどの型を持たないテーブルは読み取りのみ対応する
+		if v.IsNil() || create {
+			return i18n.Errorf(i18n.MsgKeyNotFound, seg.name)
+		}
+		return walkPath(v.Elem(), segs, create, fn)
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			if !create {
+				return i18n.Errorf(i18n.MsgKeyNotSet, seg.name)
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return walkPath(v.Elem(), segs, create, fn)
+
+	case reflect.Struct:
+		if seg.bracket {
+			return i18n.Errorf(i18n.MsgKeyNotList, seg.name)
+		}
+		field, _, ok := lookupField(v, seg.name)
+		if !ok {
+			return i18n.Errorf(i18n.MsgKeyNotFound, seg.name)
+		}
+		return walkPath(field, segs[1:], create, fn)
+
+	case reflect.Slice:
+		if !seg.bracket {
+			return i18n.Errorf(i18n.MsgKeyListElement, seg.name)
+		}
+		if seg.name == appendIndex {
+			if !create {
+				return i18n.Errorf(i18n.MsgKeyAppendOnlySet)
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := walkPath(elem, segs[1:], create, fn); err != nil {
+				return err
+			}
+			v.Set(reflect.Append(v, elem))
+			return nil
+		}
+		index, err := strconv.Atoi(seg.name)
+		if err != nil {
+			return i18n.Errorf(i18n.MsgKeyInvalidIndex, seg.name)
+		}
+		if index < 0 {
+			index += v.Len()
+		}
+		if index < 0 || index >= v.Len() {
+			return i18n.Errorf(i18n.MsgKeyIndexOutOfRange, seg.name, v.Len())
+		}
+		return walkPath(v.Index(index), segs[1:], create, fn)
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return i18n.Errorf(i18n.MsgKeyUnsupportedMap, v.Type())
+		}
+		key := reflect.ValueOf(seg.name).Convert(v.Type().Key())
+		existing := v.MapIndex(key)
+		if !existing.IsValid() && !create {
+			return i18n.Errorf(i18n.MsgKeyNotFound, seg.name)
+		}
+
+		// マップの要素はアドレス指定できないため、コピーを操作してから書き戻す
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := walkPath(elem, segs[1:], create, fn); err != nil {
+			return err
+		}
+		if create {
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+			v.SetMapIndex(key, elem)
+		}
+		return nil
+	}
+
+	return i18n.Errorf(i18n.MsgKeyNoNested, seg.name)
+}
+
+// lookupField は設定キー名またはフィールド名に一致する構造体フィールドを返す
+func lookupField(v reflect.Value, name string) (reflect.Value, reflect.StructField, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := FieldKey(field)
+		if key == "" {
+			continue
+		}
+		if strings.EqualFold(key, name) || strings.EqualFold(field.Name, name) {
+			return v.Field(i), field, true
+		}
+	}
+	return reflect.Value{}, reflect.StructField{}, false
+}
+
+// FormatValue は設定値を表示用の文字列に変換する
+// スカラー値はそのまま、リスト・マップ・セクションはTOMLのインライン値として出力する
+func FormatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		wrapper := inlineWrapper(v.Type())
+		wrapper.Elem().Field(0).Set(v)
+		data, err := toml.Marshal(wrapper.Interface())
+		if err != nil {
+			return fmt.Sprintf("%v", v.Interface())
+		}
+		return strings.TrimSpace(strings.TrimPrefix(string(data), inlineKey+" = "))
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// inlineKey はインライン値の変換に使う仮のキー名
+const inlineKey = "v"
+
+// inlineWrapper は t 型の値を単一のTOMLキーとして読み書きするための構造体を生成する
+// TOMLはトップレベルに配列やインラインテーブルを置けないため、`v = <値>` の形で変換する
+func inlineWrapper(t reflect.Type) reflect.Value {
+	st := reflect.StructOf([]reflect.StructField{{
+		Name: "V",
+		Type: t,
+		Tag:  reflect.StructTag(`toml:"` + inlineKey + `,inline"`),
+	}})
+	return reflect.New(st)
+}
+
+// parseValue は文字列を v の型に変換して設定する
+// リスト・マップ・セクションはTOMLのインライン値として解釈する (例: ["npm ci", "make"])
+func parseValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return i18n.Errorf(i18n.MsgValueInvalidDuration, value)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := parseValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return i18n.Errorf(i18n.MsgValueInvalidBool, value)
+		}
+		v.SetBool(boolValue)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intValue, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return i18n.Errorf(i18n.MsgValueInvalidNumber, value)
+		}
+		v.SetInt(intValue)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintValue, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return i18n.Errorf(i18n.MsgValueInvalidNumber, value)
+		}
+		v.SetUint(uintValue)
+	case reflect.Float32, reflect.Float64:
+		floatValue, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return i18n.Errorf(i18n.MsgValueInvalidNumber, value)
+		}
+		v.SetFloat(floatValue)
+	case reflect.Slice, reflect.Map, reflect.Struct:
+		wrapper := inlineWrapper(v.Type())
+		if err := toml.Unmarshal([]byte(inlineKey+" = "+value), wrapper.Interface()); err != nil {
+			return i18n.Errorf(i18n.MsgValueInvalidTOML, value, err)
+		}
+		v.Set(wrapper.Elem().Field(0))
+	default:
+		return i18n.Errorf(i18n.MsgValueUnsupportedType, v.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected []pathSegment
+	}{
+		{"worktree.base_dir", []pathSegment{{name: "worktree"}, {name: "base_dir"}}},
+		{"hooks.post_create[0]", []pathSegment{{name: "hooks"}, {name: "post_create"}, {name: "0", bracket: true}}},
+		{"hooks.post_create[+]", []pathSegment{{name: "hooks"}, {name: "post_create"}, {name: "+", bracket: true}}},
+		{`agents["my.agent"].command`, []pathSegment{{name: "agents"}, {name: "my.agent", bracket: true}, {name: "command"}}},
+	}
+
+	for _, tt := range tests {
+		segs, err := parsePath(tt.key)
+		if err != nil {
+			t.Errorf("parsePath(%q) returned error: %v", tt.key, err)
+			continue
+		}
+		if !reflect.DeepEqual(segs, tt.expected) {
+			t.Errorf("parsePath(%q) = %+v, expected %+v", tt.key, segs, tt.expected)
+		}
+	}
+
+	for _, key := range []string{"", "worktree.", "hooks[", "hooks[]", ".base_dir"} {
+		if _, err := parsePath(key); err == nil {
+			t.Errorf("expected error for key %q", key)
+		}
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Hooks.PostCreate = []string{"npm ci", "make"}
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"worktree.base_dir", "wtree"},
+		{"git.fetch_before_create", "true"},
+		{"Worktree.BaseDir", "wtree"},
+		{"hooks.post_create[1]", "make"},
+		{"hooks.post_create[-1]", "make"},
+		{"hooks.post_create", "['npm ci', 'make']"},
+	}
+
+	for _, tt := range tests {
+		value, err := GetValue(cfg, tt.key)
+		if err != nil {
+			t.Errorf("GetValue(%q) returned error: %v", tt.key, err)
+			continue
+		}
+		if value != tt.expected {
+			t.Errorf("GetValue(%q) = %q, expected %q", tt.key, value, tt.expected)
+		}
+	}
+
+	for _, key := range []string{"unknown.key", "worktree.unknown", "hooks.post_create[5]", "worktree.base_dir.extra"} {
+		if _, err := GetValue(cfg, key); err == nil {
+			t.Errorf("expected error for key %q", key)
+		}
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	cfg := DefaultConfig()
+
+	if err := SetValue(cfg, "worktree.base_dir", "custom"); err != nil {
+		t.Fatalf("failed to set string: %v", err)
+	}
+	if cfg.Worktree.BaseDir != "custom" {
+		t.Errorf("expected BaseDir to be 'custom', got '%s'", cfg.Worktree.BaseDir)
+	}
+
+	if err := SetValue(cfg, "ui.color_output", "false"); err != nil {
+		t.Fatalf("failed to set bool: %v", err)
+	}
+	if cfg.UI.ColorOutput {
+		t.Error("expected ColorOutput to be false")
+	}
+
+	if err := SetValue(cfg, "ui.color_output", "maybe"); err == nil {
+		t.Error("expected error for invalid bool value")
+	}
+
+	// [+] による追加
+	if err := SetValue(cfg, "hooks.post_create[+]", "npm ci"); err != nil {
+		t.Fatalf("failed to append: %v", err)
+	}
+	if err := SetValue(cfg, "hooks.post_create[+]", "make"); err != nil {
+		t.Fatalf("failed to append: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Hooks.PostCreate, []string{"npm ci", "make"}) {
+		t.Errorf("unexpected PostCreate: %v", cfg.Hooks.PostCreate)
+	}
+
+	// インデックス指定による更新
+	if err := SetValue(cfg, "hooks.post_create[0]", "pnpm install"); err != nil {
+		t.Fatalf("failed to set index: %v", err)
+	}
+	if cfg.Hooks.PostCreate[0] != "pnpm install" {
+		t.Errorf("expected first hook to be 'pnpm install', got '%s'", cfg.Hooks.PostCreate[0])
+	}
+
+	// リスト全体の置き換え
+	if err := SetValue(cfg, "hooks.pre_clear", `["git stash", "echo bye"]`); err != nil {
+		t.Fatalf("failed to set list: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Hooks.PreClear, []string{"git stash", "echo bye"}) {
+		t.Errorf("unexpected PreClear: %v", cfg.Hooks.PreClear)
+	}
+}
+
+func TestSetValueMapAndDuration(t *testing.T) {
+	type agent struct {
+		Command string        `toml:"command"`
+		Timeout time.Duration `toml:"timeout"`
+	}
+	type nested struct {
+		Agents map[string]agent `toml:"agents"`
+		Tags   map[string]int   `toml:"tags"`
+	}
+
+	n := &nested{}
+	v := reflect.ValueOf(n).Elem()
+	set := func(key, value string) error {
+		segs, err := parsePath(key)
+		if err != nil {
+			return err
+		}
+		return walkPath(v, segs, true, func(leaf reflect.Value) error {
+			return parseValue(leaf, value)
+		})
+	}
+	get := func(key string) (string, error) {
+		segs, err := parsePath(key)
+		if err != nil {
+			return "", err
+		}
+		var result string
+		err = walkPath(v, segs, false, func(leaf reflect.Value) error {
+			result = FormatValue(leaf)
+			return nil
+		})
+		return result, err
+	}
+
+	if err := set("agents.claude.command", "claude"); err != nil {
+		t.Fatalf("failed to set map entry: %v", err)
+	}
+	if err := set(`agents["claude"].timeout`, "5m"); err != nil {
+		t.Fatalf("failed to set duration: %v", err)
+	}
+	if n.Agents["claude"].Command != "claude" || n.Agents["claude"].Timeout != 5*time.Minute {
+		t.Errorf("unexpected agent: %+v", n.Agents["claude"])
+	}
+
+	if value, err := get("agents.claude.timeout"); err != nil || value != "5m0s" {
+		t.Errorf("expected '5m0s', got %q (err: %v)", value, err)
+	}
+
+	if err := set("agents.claude.timeout", "soon"); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+
+	if err := set("tags.priority", "3"); err != nil {
+		t.Fatalf("failed to set int map entry: %v", err)
+	}
+	if n.Tags["priority"] != 3 {
+		t.Errorf("expected priority to be 3, got %d", n.Tags["priority"])
+	}
+
+	if _, err := get("agents.codex.command"); err == nil {
+		t.Error("expected error for missing map key")
+	}
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ProfileEnv はプロファイルを選択する環境変数名
+const ProfileEnv = "SCION_PROFILE"
+
+// Profile は設定に重ねて適用する部分的な設定
+// Configと同じ構造のテーブルで、指定したキーのみを上書きする
+type Profile map[string]interface{}
+
+// profileReservedKeys はプロファイル内で指定できないキー
+var profileReservedKeys = []string{"version", "profiles"}
+
+// ProfileNames は定義されているプロファイル名をソートして返す
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyProfile は名前で指定したプロファイルを設定に重ねて適用する
+func (c *Config) ApplyProfile(name string) error {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return i18n.Errorf(i18n.MsgProfileNotFound, name)
+	}
+	if err := profile.applyTo(c); err != nil {
+		return i18n.Errorf(i18n.MsgProfileApplyFailed, name, err)
+	}
+	c.ActiveProfile = name
+	return nil
+}
+
+// applyTo はプロファイルの値を cfg に上書きする
+// Configに存在しないキーが含まれる場合はエラーを返す
+func (p Profile) applyTo(cfg *Config) error {
+	for _, key := range profileReservedKeys {
+		if _, ok := p[key]; ok {
+			return i18n.Errorf(i18n.MsgProfileReservedKey, key)
+		}
+	}
+
+	data, err := toml.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	decoder := toml.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(cfg)
+}
+
+// setProfileValue はプロファイル内のキーに値を設定する
+// 値はConfigの型に従って検証・変換してからプロファイルに格納する
+func setProfileValue(cfg *Config, name string, segs []pathSegment, value string) error {
+	for _, key := range profileReservedKeys {
+		if !segs[0].bracket && segs[0].name == key {
+			return i18n.Errorf(i18n.MsgProfileReservedKey, key)
+		}
+	}
+
+	profile := cfg.Profiles[name]
+
+	// プロファイルの現在値を空の設定に展開し、型に従って値を設定する
+	scratch := &Config{}
+	if err := profile.applyTo(scratch); err != nil {
+		return i18n.Errorf(i18n.MsgProfileLoadFailed, name, err)
+	}
+	root := reflect.ValueOf(scratch).Elem()
+	if err := walkPath(root, segs, true, func(v reflect.Value) error {
+		return parseValue(v, value)
+	}); err != nil {
+		return err
+	}
+
+	// 変更された設定項目（セクション配下の最初の非構造体フィールド）をプロファイルに書き戻す
+	keys, field, err := profileField(root, segs)
+	if err != nil {
+		return err
+	}
+	raw, err := toRawValue(field)
+	if err != nil {
+		return err
+	}
+
+	if profile == nil {
+		profile = make(Profile)
+	}
+	table := map[string]interface{}(profile)
+	for _, key := range keys[:len(keys)-1] {
+		table = subTable(table, key, true)
+	}
+	table[keys[len(keys)-1]] = raw
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]Profile)
+	}
+	cfg.Profiles[name] = profile
+	return nil
+}
+
+// profileField はパスをたどり、構造体ではない最初のフィールドとその設定キーの一覧を返す
+func profileField(v reflect.Value, segs []pathSegment) ([]string, reflect.Value, error) {
+	var keys []string
+	for _, seg := range segs {
+		if v.Kind() != reflect.Struct {
+			break
+		}
+		field, structField, ok := lookupField(v, seg.name)
+		if !ok {
+			return nil, reflect.Value{}, i18n.Errorf(i18n.MsgKeyNotFound, seg.name)
+		}
+		keys = append(keys, FieldKey(structField))
+		v = field
+	}
+	if v.Kind() == reflect.Struct {
+		return nil, reflect.Value{}, i18n.Errorf(i18n.MsgProfileSectionNotAllowed)
+	}
+	return keys, v, nil
+}
+
+// toRawValue は設定値をTOMLドキュメントとして格納できる汎用の値に変換する
+func toRawValue(v reflect.Value) (interface{}, error) {
+	wrapper := inlineWrapper(v.Type())
+	wrapper.Elem().Field(0).Set(v)
+	data, err := toml.Marshal(wrapper.Interface())
+	if err != nil {
+		return nil, err
+	}
+	doc := make(map[string]interface{})
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc[inlineKey], nil
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestApplyProfile(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Profiles = map[string]Profile{
+		"quick": {
+			"git": map[string]interface{}{
+				"fetch_before_create": false,
+			},
+		},
+	}
+
+	if err := cfg.ApplyProfile("quick"); err != nil {
+		t.Fatalf("failed to apply profile: %v", err)
+	}
+
+	if cfg.Git.FetchBeforeCreate {
+		t.Error("expected FetchBeforeCreate to be overridden to false")
+	}
+
+	// プロファイルで指定していない値は維持される
+	if cfg.Git.DefaultRemote != "origin" {
+		t.Errorf("expected DefaultRemote to be kept as 'origin', got '%s'", cfg.Git.DefaultRemote)
+	}
+
+	if cfg.ActiveProfile != "quick" {
+		t.Errorf("expected ActiveProfile to be 'quick', got '%s'", cfg.ActiveProfile)
+	}
+}
+
+func TestApplyProfileErrors(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Profiles = map[string]Profile{
+		"typo": {
+			"git": map[string]interface{}{
+				"fetch_befor_create": false,
+			},
+		},
+		"nested": {
+			"profiles": map[string]interface{}{},
+		},
+	}
+
+	if err := cfg.ApplyProfile("missing"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+
+	if err := cfg.ApplyProfile("typo"); err == nil {
+		t.Error("expected error for unknown key in profile")
+	}
+
+	if err := cfg.ApplyProfile("nested"); err == nil {
+		t.Error("expected error for nested profiles")
+	}
+}
+
+func TestSetProfileValue(t *testing.T) {
+	cfg := DefaultConfig()
+
+	if err := SetValue(cfg, "profiles.claude.git.fetch_before_create", "true"); err != nil {
+		t.Fatalf("failed to set profile value: %v", err)
+	}
+	if err := SetValue(cfg, "profiles.claude.hooks.post_create[+]", "npm ci"); err != nil {
+		t.Fatalf("failed to append profile value: %v", err)
+	}
+	if err := SetValue(cfg, "profiles.claude.hooks.post_create[+]", "make"); err != nil {
+		t.Fatalf("failed to append profile value: %v", err)
+	}
+
+	value, err := GetValue(cfg, "profiles.claude.git.fetch_before_create")
+	if err != nil || value != "true" {
+		t.Errorf("expected 'true', got %q (err: %v)", value, err)
+	}
+
+	// 型の検証
+	if err := SetValue(cfg, "profiles.claude.git.fetch_before_create", "sometimes"); err == nil {
+		t.Error("expected error for invalid bool value")
+	}
+	if err := SetValue(cfg, "profiles.claude.git.unknown", "x"); err == nil {
+		t.Error("expected error for unknown key")
+	}
+	if err := SetValue(cfg, "profiles.claude.version", "2"); err == nil {
+		t.Error("expected error for reserved key")
+	}
+
+	// 設定したプロファイルを適用できる
+	if err := cfg.ApplyProfile("claude"); err != nil {
+		t.Fatalf("failed to apply profile: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Hooks.PostCreate, []string{"npm ci", "make"}) {
+		t.Errorf("unexpected PostCreate: %v", cfg.Hooks.PostCreate)
+	}
+}
+
+func TestProfileSaveAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `version = 1
+
+[git]
+default_remote = "upstream"
+
+[profiles.quick.git]
+fetch_before_create = false
+
+[profiles.claude.hooks]
+post_create = ["npm ci"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	if err := loadFromFile(configPath, cfg); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if names := cfg.ProfileNames(); !reflect.DeepEqual(names, []string{"claude", "quick"}) {
+		t.Errorf("unexpected profile names: %v", names)
+	}
+
+	if err := cfg.ApplyProfile("quick"); err != nil {
+		t.Fatalf("failed to apply profile: %v", err)
+	}
+	if cfg.Git.FetchBeforeCreate {
+		t.Error("expected FetchBeforeCreate to be false")
+	}
+	if cfg.Git.DefaultRemote != "upstream" {
+		t.Errorf("expected DefaultRemote from file to be kept, got '%s'", cfg.Git.DefaultRemote)
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"path"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// Rule はブランチ名のパターンごとに適用する設定
+// 空の項目はルール適用前の設定値を引き継ぐ
+type Rule struct {
+	Pattern           string       `toml:"pattern" comment:"対象ブランチのglobパターン (例: hotfix/*)"`
+	BaseBranch        string       `toml:"base_branch,omitempty" comment:"worktreeのブランチを切るベースブランチ"`
+	Remote            string       `toml:"remote,omitempty" comment:"使用するリモート名"`
+	Placement         string       `toml:"placement,omitempty" comment:"worktreeの配置方法"`
+	BaseDir           string       `toml:"base_dir,omitempty" comment:"worktreeディレクトリのベース名"`
+	DirTemplate       string       `toml:"dir_template,omitempty" comment:"worktreeのパスのテンプレート"`
+	FetchBeforeCreate *bool        `toml:"fetch_before_create,omitempty" comment:"worktree作成前にfetchを実行"`
+	PushOnCreate      *bool        `toml:"push_on_create,omitempty" comment:"作成した新しいブランチをリモートにpush"`
+	SparsePaths       []string     `toml:"sparse_paths,omitempty" comment:"sparse checkout でチェックアウトするディレクトリ (空のリストで無効化)"`
+	Hooks             *HooksConfig `toml:"hooks,omitempty" comment:"このルールで使用するフック (指定時は既存のフックを置き換える)"`
+}
+
+// Matches はブランチ名がルールのパターンに一致するかどうかを返す
+// パターンの構文は path.Match に従い、'*' は '/' に一致しない
+func (r *Rule) Matches(branch string) (bool, error) {
+	if r.Pattern == "" {
+		return false, i18n.Errorf(i18n.MsgRulePatternMissing)
+	}
+	matched, err := path.Match(r.Pattern, branch)
+	if err != nil {
+		return false, i18n.Errorf(i18n.MsgRulePatternInvalid, r.Pattern, err)
+	}
+	return matched, nil
+}
+
+// MatchingRules はブランチ名に一致するルールを定義順に返す
+func (c *Config) MatchingRules(branch string) ([]Rule, error) {
+	var matched []Rule
+	for _, rule := range c.Rules {
+		ok, err := rule.Matches(branch)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, rule)
+		}
+	}
+	return matched, nil
+}
+
+// ForBranch はブランチ名に一致するルールを適用した設定のコピーを返す
+// 一致するルールが複数ある場合は定義順に適用し、後のルールが優先される
+func (c *Config) ForBranch(branch string) (*Config, error) {
+	rules, err := c.MatchingRules(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	derived := *c
+	for _, rule := range rules {
+		if rule.BaseBranch != "" {
+			derived.Git.DefaultBaseBranch = rule.BaseBranch
+		}
+		if rule.Remote != "" {
+			derived.Git.DefaultRemote = rule.Remote
+		}
+		if rule.Placement != "" {
+			derived.Worktree.Placement = rule.Placement
+		}
+		if rule.BaseDir != "" {
+			derived.Worktree.BaseDir = rule.BaseDir
+		}
+		if rule.DirTemplate != "" {
+			derived.Worktree.DirTemplate = rule.DirTemplate
+		}
+		if rule.FetchBeforeCreate != nil {
+			derived.Git.FetchBeforeCreate = *rule.FetchBeforeCreate
+		}
+		if rule.PushOnCreate != nil {
+			derived.Git.PushOnCreate = *rule.PushOnCreate
+		}
+		if rule.SparsePaths != nil {
+			derived.Worktree.SparsePaths = rule.SparsePaths
+		}
+		if rule.Hooks != nil {
+			derived.Hooks = *rule.Hooks
+		}
+	}
+	return &derived, nil
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRuleMatches(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		branch   string
+		expected bool
+	}{
+		{"hotfix/*", "hotfix/login", true},
+		{"hotfix/*", "hotfix/a/b", false},
+		{"hotfix/*", "feature/login", false},
+		{"release-?", "release-1", true},
+		{"*", "main", true},
+	}
+
+	for _, tt := range tests {
+		rule := Rule{Pattern: tt.pattern}
+		matched, err := rule.Matches(tt.branch)
+		if err != nil {
+			t.Errorf("Matches(%q, %q) returned error: %v", tt.pattern, tt.branch, err)
+			continue
+		}
+		if matched != tt.expected {
+			t.Errorf("Matches(%q, %q) = %v, expected %v", tt.pattern, tt.branch, matched, tt.expected)
+		}
+	}
+
+	if _, err := (&Rule{}).Matches("main"); err == nil {
+		t.Error("expected error for empty pattern")
+	}
+	if _, err := (&Rule{Pattern: "[invalid"}).Matches("main"); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestForBranch(t *testing.T) {
+	noFetch := false
+	cfg := DefaultConfig()
+	cfg.Hooks.PostCreate = []string{"npm ci"}
+	cfg.Rules = []Rule{
+		{Pattern: "hotfix/*", BaseBranch: "release", Remote: "upstream"},
+		{Pattern: "hotfix/urgent-*", BaseDir: "hotfix-trees", FetchBeforeCreate: &noFetch, Hooks: &HooksConfig{}},
+		{Pattern: "hotfix/*", SparsePaths: []string{"services/api"}},
+	}
+
+	derived, err := cfg.ForBranch("hotfix/urgent-login")
+	if err != nil {
+		t.Fatalf("failed to apply rules: %v", err)
+	}
+
+	if derived.Git.DefaultBaseBranch != "release" {
+		t.Errorf("expected base branch 'release', got '%s'", derived.Git.DefaultBaseBranch)
+	}
+	if derived.Git.DefaultRemote != "upstream" {
+		t.Errorf("expected remote 'upstream', got '%s'", derived.Git.DefaultRemote)
+	}
+	if derived.Worktree.BaseDir != "hotfix-trees" {
+		t.Errorf("expected base dir 'hotfix-trees', got '%s'", derived.Worktree.BaseDir)
+	}
+	if derived.Git.FetchBeforeCreate {
+		t.Error("expected FetchBeforeCreate to be false")
+	}
+	if len(derived.Hooks.PostCreate) != 0 {
+		t.Errorf("expected hooks to be replaced, got %v", derived.Hooks.PostCreate)
+	}
+	if !reflect.DeepEqual(derived.Worktree.SparsePaths, []string{"services/api"}) {
+		t.Errorf("unexpected sparse paths: %v", derived.Worktree.SparsePaths)
+	}
+
+	// 元の設定は変更されない
+	if cfg.Git.DefaultBaseBranch != "main" || !reflect.DeepEqual(cfg.Hooks.PostCreate, []string{"npm ci"}) {
+		t.Error("expected original config to be unchanged")
+	}
+
+	// 一致しないブランチはそのまま
+	derived, err = cfg.ForBranch("feature/login")
+	if err != nil {
+		t.Fatalf("failed to apply rules: %v", err)
+	}
+	if derived.Git.DefaultBaseBranch != "main" || derived.Worktree.BaseDir != "wtree" || derived.Worktree.SparsePaths != nil {
+		t.Error("expected defaults for non-matching branch")
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `version = 1
+
+[[rules]]
+pattern = "hotfix/*"
+base_branch = "release"
+remote = "upstream"
+
+[rules.hooks]
+post_create = ["make deps"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	if err := loadFromFile(configPath, cfg); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if len(cfg.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(cfg.Rules))
+	}
+
+	derived, err := cfg.ForBranch("hotfix/x")
+	if err != nil {
+		t.Fatalf("failed to apply rules: %v", err)
+	}
+	if !reflect.DeepEqual(derived.Hooks.PostCreate, []string{"make deps"}) {
+		t.Errorf("unexpected hooks: %v", derived.Hooks.PostCreate)
+	}
+
+	// パスリゾルバーでルールを追加できる
+	if err := SetValue(cfg, "rules[+]", `{pattern = "docs/*", base_dir = "docs-trees"}`); err != nil {
+		t.Fatalf("failed to append rule: %v", err)
+	}
+	if value, err := GetValue(cfg, "rules[1].base_dir"); err != nil || value != "docs-trees" {
+		t.Errorf("expected 'docs-trees', got %q (err: %v)", value, err)
+	}
+}
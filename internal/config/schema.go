@@ -0,0 +1,188 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// SchemaURI は生成するJSON Schemaのドラフトバージョン
+const SchemaURI = "http://json-schema.org/draft-07/schema#"
+
+// Schema はJSON Schemaのノードを表す
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+}
+
+// durationType はtime.Durationの型情報
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// GenerateSchema はConfig構造体からJSON Schemaを生成する
+// 説明は現在の言語に翻訳したメッセージ（descriptionID）、列挙値は `enum` タグ（カンマ区切り）、デフォルト値はDefaultConfigから取得する
+func GenerateSchema() *Schema {
+	s := schemaFor(reflect.TypeOf(Config{}), reflect.ValueOf(*DefaultConfig()))
+	s.Schema = SchemaURI
+	s.Title = "scion configuration"
+	s.Description = i18n.Text(i18n.MsgDescConfig)
+
+	// プロファイルはversion・profiles以外の設定と同じ構造を持つ（デフォルト値は持たない）
+	overlay := schemaFor(reflect.TypeOf(Config{}), reflect.Value{})
+	for _, key := range profileReservedKeys {
+		delete(overlay.Properties, key)
+	}
+	s.Properties["profiles"].AdditionalProperties = overlay
+	return s
+}
+
+// SchemaJSON はJSON Schemaを整形済みのJSONとして返す
+func SchemaJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(GenerateSchema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// schemaFor は型に対応するスキーマを生成する
+// def が有効な場合はその値をデフォルト値として埋め込む
+func schemaFor(t reflect.Type, def reflect.Value) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if def.IsValid() {
+			if def.IsNil() {
+				def = reflect.Value{}
+			} else {
+				def = def.Elem()
+			}
+		}
+	}
+
+	if t == durationType {
+		s := &Schema{Type: "string", Pattern: `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`}
+		if def.IsValid() && def.Int() != 0 {
+			s.Default = time.Duration(def.Int()).String()
+		}
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		s := &Schema{
+			Type:                 "object",
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: false,
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			key := FieldKey(field)
+			if key == "" {
+				continue
+			}
+			var fieldDef reflect.Value
+			if def.IsValid() {
+				fieldDef = def.Field(i)
+			}
+			prop := schemaFor(field.Type, fieldDef)
+			prop.Description = fieldDescription(t, field, key)
+			if enum := field.Tag.Get("enum"); enum != "" {
+				prop.Enum = strings.Split(enum, ",")
+			}
+			s.Properties[key] = prop
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		s := &Schema{Type: "array", Items: schemaFor(t.Elem(), reflect.Value{})}
+		if def.IsValid() && def.Len() > 0 {
+			s.Default = def.Interface()
+		}
+		return s
+	case reflect.Map:
+		s := &Schema{Type: "object", AdditionalProperties: schemaFor(t.Elem(), reflect.Value{})}
+		if def.IsValid() && def.Len() > 0 {
+			s.Default = def.Interface()
+		}
+		return s
+	}
+
+	s := &Schema{Type: jsonType(t.Kind())}
+	if def.IsValid() {
+		s.Default = def.Interface()
+	}
+	return s
+}
+
+// descriptionID は構造体のフィールドの説明のメッセージID（config.desc.<セクション>.<キー>）を返す
+// セクションは型名から Config を除いて小文字にしたもの（WorktreeConfig は worktree、Rule は rule）で、Config のフィールドはセクションを持たない
+// 同じ型はどこに現れても（rules[].hooks など）同じ説明を使う
+func descriptionID(t reflect.Type, key string) i18n.MessageID {
+	section := strings.ToLower(strings.TrimSuffix(t.Name(), "Config"))
+	if section == "" {
+		return i18n.MessageID("config.desc." + key)
+	}
+	return i18n.MessageID("config.desc." + section + "." + key)
+}
+
+// fieldDescription はフィールドの説明を現在の言語で返す
+// カタログにない場合は `comment` タグ（設定ファイルに書き出すコメント）を使う
+func fieldDescription(t reflect.Type, field reflect.StructField, key string) string {
+	id := descriptionID(t, key)
+	if text := i18n.Text(id); text != string(id) {
+		return text
+	}
+	return field.Tag.Get("comment")
+}
+
+// jsonType はGoの型の種類をJSON Schemaの型名に変換する
+func jsonType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// FieldKey は構造体フィールドの設定キー名（tomlタグ）を返す
+// tomlタグが "-" の場合は空文字列を返す
+func FieldKey(field reflect.StructField) string {
+	tag := field.Tag.Get("toml")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return toSnakeCase(field.Name)
+}
+
+// toSnakeCase はCamelCaseをsnake_caseに変換する
+func toSnakeCase(s string) string {
+	var result strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			result.WriteRune('_')
+		}
+		result.WriteRune(r)
+	}
+	return strings.ToLower(result.String())
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"unicode"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	s := GenerateSchema()
+
+	if s.Schema != SchemaURI {
+		t.Errorf("expected $schema to be '%s', got '%s'", SchemaURI, s.Schema)
+	}
+
+	if s.Type != "object" {
+		t.Errorf("expected root type to be 'object', got '%s'", s.Type)
+	}
+
+	// すべてのセクションが含まれていることを確認
+	for _, section := range []string{"repository", "worktree", "git", "ui", "editor"} {
+		if _, ok := s.Properties[section]; !ok {
+			t.Errorf("expected section '%s' in schema", section)
+		}
+	}
+
+	baseDir := s.Properties["worktree"].Properties["base_dir"]
+	if baseDir == nil {
+		t.Fatal("expected worktree.base_dir in schema")
+	}
+	if baseDir.Type != "string" {
+		t.Errorf("expected base_dir type to be 'string', got '%s'", baseDir.Type)
+	}
+	if baseDir.Default != "wtree" {
+		t.Errorf("expected base_dir default to be 'wtree', got '%v'", baseDir.Default)
+	}
+	if baseDir.Description == "" {
+		t.Error("expected base_dir to have a description")
+	}
+
+	fetch := s.Properties["git"].Properties["fetch_before_create"]
+	if fetch == nil || fetch.Type != "boolean" {
+		t.Fatalf("expected git.fetch_before_create to be boolean, got %+v", fetch)
+	}
+	if fetch.Default != true {
+		t.Errorf("expected fetch_before_create default to be true, got '%v'", fetch.Default)
+	}
+}
+
+func TestGenerateSchemaCoversAllFields(t *testing.T) {
+	s := GenerateSchema()
+
+	// Config構造体のフィールドとスキーマのプロパティが一致することを確認
+	ct := reflect.TypeOf(Config{})
+	for i := 0; i < ct.NumField(); i++ {
+		section := ct.Field(i)
+		if FieldKey(section) == "" {
+			continue
+		}
+		sectionSchema, ok := s.Properties[FieldKey(section)]
+		if !ok {
+			t.Errorf("section '%s' missing from schema", FieldKey(section))
+			continue
+		}
+		if section.Type.Kind() != reflect.Struct {
+			continue
+		}
+		for j := 0; j < section.Type.NumField(); j++ {
+			key := FieldKey(section.Type.Field(j))
+			if _, ok := sectionSchema.Properties[key]; !ok {
+				t.Errorf("field '%s.%s' missing from schema", FieldKey(section), key)
+			}
+		}
+	}
+}
+
+func TestSchemaDescriptionsAreTranslated(t *testing.T) {
+	original := i18n.CurrentLocale()
+	t.Cleanup(func() { i18n.SetLocale(original) })
+
+	// 英語ではすべての説明がカタログの英語のメッセージになる（rules の要素やプロファイルの中も含む）
+	i18n.SetLocale(i18n.English)
+	var walk func(path string, s *Schema)
+	walk = func(path string, s *Schema) {
+		for key, prop := range s.Properties {
+			if prop.Description == "" {
+				t.Errorf("expected %s.%s to have a description", path, key)
+			}
+			for _, r := range prop.Description {
+				if r > unicode.MaxASCII {
+					t.Errorf("expected English description for %s.%s, got %q", path, key, prop.Description)
+					break
+				}
+			}
+			walk(path+"."+key, prop)
+		}
+		if s.Items != nil {
+			walk(path+"[]", s.Items)
+		}
+		if sub, ok := s.AdditionalProperties.(*Schema); ok {
+			walk(path+".*", sub)
+		}
+	}
+	walk("config", GenerateSchema())
+
+	i18n.SetLocale(i18n.Japanese)
+	if desc := GenerateSchema().Properties["worktree"].Properties["base_dir"].Description; desc != i18n.Text(i18n.MsgDescWorktreeBaseDir) {
+		t.Errorf("expected Japanese description for worktree.base_dir, got %q", desc)
+	}
+	for _, key := range Keys() {
+		if key.Name == "hooks.pre_land" && key.Description != i18n.Text(i18n.MsgDescHooksPreLand) {
+			t.Errorf("expected Japanese description for hooks.pre_land, got %q", key.Description)
+		}
+	}
+}
+
+func TestSchemaEnum(t *testing.T) {
+	type enumConfig struct {
+		Mode string `toml:"mode" enum:"merge,rebase"`
+	}
+
+	s := schemaFor(reflect.TypeOf(enumConfig{}), reflect.ValueOf(enumConfig{Mode: "merge"}))
+	mode := s.Properties["mode"]
+	if !reflect.DeepEqual(mode.Enum, []string{"merge", "rebase"}) {
+		t.Errorf("expected enum [merge rebase], got %v", mode.Enum)
+	}
+	if mode.Default != "merge" {
+		t.Errorf("expected default 'merge', got '%v'", mode.Default)
+	}
+}
+
+func TestSchemaJSON(t *testing.T) {
+	data, err := SchemaJSON()
+	if err != nil {
+		t.Fatalf("failed to generate schema JSON: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	if decoded["additionalProperties"] != false {
+		t.Errorf("expected additionalProperties to be false, got %v", decoded["additionalProperties"])
+	}
+}
@@ -0,0 +1,120 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// エラーの種類
+// 返されるエラーは翻訳済みのメッセージを持ち、種類は errors.Is で判定する
+var (
+	// ErrNotGitRepo はGitリポジトリの外で実行された
+	ErrNotGitRepo = errors.New("not a git repository")
+	// ErrWorktreeExists は作成しようとした worktree が既に存在する
+	ErrWorktreeExists = errors.New("worktree already exists")
+	// ErrUncommittedChanges は worktree に未コミットの変更がある
+	ErrUncommittedChanges = errors.New("uncommitted changes")
+	// ErrBranchNotFound はブランチまたはその worktree が見つからない
+	ErrBranchNotFound = errors.New("branch not found")
+	// ErrConflict はリベースやマージが衝突した
+	ErrConflict = errors.New("conflict")
+	// ErrGitFailed はgitコマンドが失敗した（詳細は CommandError で取得できる）
+	ErrGitFailed = errors.New("git command failed")
+)
+
+// ErrNothingToSquash は MergeSquash でまとめた結果に差分がなく、コミットしなかった
+// エラーの種類ではなく、呼び出し側で取り込み済みとして扱う
+var ErrNothingToSquash = errors.New("nothing to squash")
+
+// Error は種類を持つエラー
+type Error struct {
+	// Kind はエラーの種類 (ErrNotGitRepo など)
+	Kind  error
+	msg   string
+	cause error
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Unwrap は種類と原因のエラーを返す
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.cause}
+}
+
+// Errorf は翻訳したメッセージから種類を持つエラーを生成する
+// i18n.Errorf と同様に %w でエラーをラップできる
+func Errorf(kind error, id i18n.MessageID, args ...interface{}) error {
+	err := i18n.Errorf(id, args...)
+	return &Error{Kind: kind, msg: err.Error(), cause: err}
+}
+
+// CommandError は失敗したgitコマンドの情報
+type CommandError struct {
+	// Args はgitに渡した引数
+	Args []string
+	// Stderr はコマンドの標準エラー出力
+	Stderr string
+	// ExitCode はコマンドの終了コード（起動できなかった場合は -1）
+	ExitCode int
+	// Err は exec パッケージが返したエラー
+	Err error
+}
+
+// newCommandError は実行したコマンドと標準エラー出力から CommandError を生成する
+func newCommandError(cmd *exec.Cmd, stderr string, err error) *CommandError {
+	exitCode := -1
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	}
+	return &CommandError{Args: cmd.Args[1:], Stderr: collapseProgress(stderr), ExitCode: exitCode, Err: err}
+}
+
+// collapseProgress は \r で上書きされた進捗の表示を、端末に最後に表示される内容だけにまとめる
+func collapseProgress(stderr string) string {
+	lines := strings.Split(stderr, "\n")
+	for i, line := range lines {
+		if j := strings.LastIndex(strings.TrimRight(line, "\r"), "\r"); j >= 0 {
+			lines[i] = line[j+1:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("git %s: %s", strings.Join(e.Args, " "), e.detail())
+}
+
+// detail は標準エラー出力、なければ exec のエラーを返す
+func (e *CommandError) detail() string {
+	if stderr := strings.TrimSpace(e.Stderr); stderr != "" {
+		return stderr
+	}
+	return e.Err.Error()
+}
+
+// Is は ErrGitFailed との比較で true を返す
+func (e *CommandError) Is(target error) bool {
+	return target == ErrGitFailed
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// commandFailed はgitコマンドの失敗を翻訳したメッセージで包む
+// メッセージにはコマンドの標準エラー出力を埋め込み、元の CommandError は errors.As で取得できる
+func commandFailed(kind error, id i18n.MessageID, err error) error {
+	detail := err.Error()
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		detail = cmdErr.detail()
+	}
+	return &Error{Kind: kind, msg: i18n.T(id, detail), cause: err}
+}
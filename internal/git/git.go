@@ -2,53 +2,256 @@ package git
 
 import (
 	"bytes"
-	"fmt"
+	"errors"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
 )
 
+// gitCommand は git コマンドを生成する
+func gitCommand(args ...string) *exec.Cmd {
+	return exec.Command("git", args...)
+}
+
+// run はコマンドを実行し、コマンドと所要時間を traceCommand に渡す
+// 失敗した場合は標準エラー出力を含む *CommandError を返す
+func run(cmd *exec.Cmd) error {
+	stderr := captureStderr(cmd)
+	start := time.Now()
+	err := cmd.Run()
+	traceCommand(cmd, time.Since(start), err)
+	if err != nil {
+		return newCommandError(cmd, stderr.String(), err)
+	}
+	return nil
+}
+
+// runOutput はコマンドを実行して標準出力を返し、コマンドと所要時間を traceCommand に渡す
+// 失敗した場合は標準エラー出力を含む *CommandError を返す
+func runOutput(cmd *exec.Cmd) ([]byte, error) {
+	stderr := captureStderr(cmd)
+	start := time.Now()
+	out, err := cmd.Output()
+	traceCommand(cmd, time.Since(start), err)
+	if err != nil {
+		return out, newCommandError(cmd, stderr.String(), err)
+	}
+	return out, nil
+}
+
+// captureStderr はコマンドの標準エラー出力を記録するバッファを設定する
+// 既に出力先が設定されている場合は、その出力先にも書き込む
+func captureStderr(cmd *exec.Cmd) *bytes.Buffer {
+	var stderr bytes.Buffer
+	if cmd.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, &stderr)
+	} else {
+		cmd.Stderr = &stderr
+	}
+	return &stderr
+}
+
+// CommandTracer は実行したコマンドの引数、所要時間、エラーを受け取る関数
+type CommandTracer func(args []string, elapsed time.Duration, err error)
+
+// commandTracer は SetCommandTracer で設定された関数
+var commandTracer CommandTracer
+
+// SetCommandTracer はコマンドを実行するたびに呼ぶ関数を設定する
+// デバッグ出力などに使用する。nil を指定すると解除する
+func SetCommandTracer(tracer CommandTracer) {
+	commandTracer = tracer
+}
+
+// traceCommand は実行したコマンドを SetCommandTracer で設定された関数に渡す
+func traceCommand(cmd *exec.Cmd, elapsed time.Duration, err error) {
+	if commandTracer != nil {
+		commandTracer(cmd.Args, elapsed, err)
+	}
+}
+
 // IsGitRepository は現在のディレクトリがGitリポジトリ内かどうかを確認する
 func IsGitRepository() bool {
-	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
-	output, err := cmd.Output()
+	cmd := gitCommand("rev-parse", "--is-inside-work-tree")
+	out, err := runOutput(cmd)
 	if err != nil {
 		return false
 	}
-	return strings.TrimSpace(string(output)) == "true"
+	return strings.TrimSpace(string(out)) == "true"
 }
 
 // GetRepositoryRoot はGitリポジトリのルートパスを返す
 func GetRepositoryRoot() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-	output, err := cmd.Output()
+	cmd := gitCommand("rev-parse", "--show-toplevel")
+	out, err := runOutput(cmd)
 	if err != nil {
-		return "", fmt.Errorf("Gitリポジトリのルートを取得できません: %w", err)
+		return "", commandFailed(ErrGitFailed, i18n.MsgGitRootFailed, err)
 	}
-	return strings.TrimSpace(string(output)), nil
+	return strings.TrimSpace(string(out)), nil
 }
 
 // GetCurrentBranch は現在のブランチ名を返す
 func GetCurrentBranch() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-	output, err := cmd.Output()
+	cmd := gitCommand("rev-parse", "--abbrev-ref", "HEAD")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return "", commandFailed(ErrGitFailed, i18n.MsgGitCurrentBranchFailed, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GetCommonDir はすべての worktree で共有される Gitディレクトリ（メインworktreeの .git）の絶対パスを返す
+func GetCommonDir() (string, error) {
+	cmd := gitCommand("rev-parse", "--path-format=absolute", "--git-common-dir")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return "", commandFailed(ErrGitFailed, i18n.MsgGitRootFailed, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GetMainWorktreeRoot はメインworktree（元のリポジトリ）のルートを返す
+// どの worktree から実行しても同じリポジトリとして扱うために使う
+func GetMainWorktreeRoot() (string, error) {
+	dir, err := GetCommonDir()
 	if err != nil {
-		return "", fmt.Errorf("現在のブランチを取得できません: %w", err)
+		return "", err
 	}
-	return strings.TrimSpace(string(output)), nil
+	return filepath.Dir(dir), nil
+}
+
+// HeadCommit は worktree でチェックアウトしているコミットのハッシュを返す
+func HeadCommit(worktreePath string) (string, error) {
+	cmd := gitCommand("-C", worktreePath, "rev-parse", "--verify", "HEAD")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return "", commandFailed(ErrGitFailed, i18n.MsgGitHeadFailed, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ResolveCommit はブランチ名や ref などのリビジョンが指すコミットのハッシュを返す
+func ResolveCommit(revision string) (string, error) {
+	cmd := gitCommand("rev-parse", "--verify", revision+"^{commit}")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return "", commandFailed(ErrBranchNotFound, i18n.MsgGitResolveFailed, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// IsAncestorOf は ancestor が revision の祖先または revision 自身であるかどうかを返す
+func IsAncestorOf(ancestor, revision string) bool {
+	cmd := gitCommand("merge-base", "--is-ancestor", ancestor, revision)
+	return run(cmd) == nil
+}
+
+// UpdateBranch はブランチを newCommit に移動する
+// ブランチが oldCommit を指していない場合（他の操作で更新された場合）は変更せずにエラーを返す
+// worktree でチェックアウトしているブランチのファイルは更新しないため、チェックアウトされていないブランチに使用する
+func UpdateBranch(branchName, newCommit, oldCommit string) error {
+	cmd := gitCommand("update-ref", "refs/heads/"+branchName, newCommit, oldCommit)
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitUpdateRefFailed, err)
+	}
+	return nil
 }
 
 // BranchExists はブランチが存在するかどうかを確認する
 func BranchExists(branchName string) bool {
-	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branchName)
-	return cmd.Run() == nil
+	cmd := gitCommand("show-ref", "--verify", "--quiet", "refs/heads/"+branchName)
+	return run(cmd) == nil
+}
+
+// RemoteBranchExists はリモート追跡ブランチ（refs/remotes/<remote>/<branch>）が存在するかどうかを確認する
+func RemoteBranchExists(remote, branchName string) bool {
+	cmd := gitCommand("show-ref", "--verify", "--quiet", "refs/remotes/"+remote+"/"+branchName)
+	return run(cmd) == nil
+}
+
+// SplitRemoteBranch は origin/feature-x のようなリモート追跡ブランチ名をリモート名とブランチ名に分ける
+// リモート名に / を含む場合に備え、登録済みのリモートのうち最も長く一致するものを使用する
+func SplitRemoteBranch(name string) (remote, branchName string, ok bool) {
+	remotes, err := ListRemotes()
+	if err != nil {
+		return "", "", false
+	}
+	for _, r := range remotes {
+		rest, found := strings.CutPrefix(name, r+"/")
+		if found && rest != "" && len(r) > len(remote) {
+			remote, branchName = r, rest
+		}
+	}
+	return remote, branchName, remote != ""
+}
+
+// ListTags はタグの名前を返す
+func ListTags() ([]string, error) {
+	cmd := gitCommand("for-each-ref", "--format=%(refname:short)", "refs/tags")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return nil, commandFailed(ErrGitFailed, i18n.MsgGitTagListFailed, err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// ListBranches はローカルブランチの名前を返す
+// includeRemote が true の場合はリモート追跡ブランチ（origin/main など）も含める
+func ListBranches(includeRemote bool) ([]string, error) {
+	args := []string{"for-each-ref", "--format=%(refname)", "refs/heads"}
+	if includeRemote {
+		args = append(args, "refs/remotes")
+	}
+
+	cmd := gitCommand(args...)
+	out, err := runOutput(cmd)
+	if err != nil {
+		return nil, commandFailed(ErrGitFailed, i18n.MsgGitBranchListFailed, err)
+	}
+
+	var branches []string
+	for _, ref := range strings.Fields(string(out)) {
+		// origin/HEAD はデフォルトブランチへの参照のため除外する
+		if strings.HasPrefix(ref, "refs/remotes/") && strings.HasSuffix(ref, "/HEAD") {
+			continue
+		}
+		ref = strings.TrimPrefix(ref, "refs/heads/")
+		ref = strings.TrimPrefix(ref, "refs/remotes/")
+		branches = append(branches, ref)
+	}
+	return branches, nil
+}
+
+// ListRemotes はリモートの名前を返す
+func ListRemotes() ([]string, error) {
+	cmd := gitCommand("remote")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return nil, commandFailed(ErrGitFailed, i18n.MsgGitRemoteListFailed, err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// RemoteURL はリモートのURLを返す
+func RemoteURL(remote string) (string, error) {
+	cmd := gitCommand("remote", "get-url", remote)
+	out, err := runOutput(cmd)
+	if err != nil {
+		return "", commandFailed(ErrGitFailed, i18n.MsgGitRemoteURLFailed, err)
+	}
+	return strings.TrimSpace(string(out)), nil
 }
 
 // WorktreeExists はworktreeが存在するかどうかを確認する
 func WorktreeExists(path string) bool {
-	cmd := exec.Command("git", "worktree", "list", "--porcelain")
-	output, err := cmd.Output()
+	cmd := gitCommand("worktree", "list", "--porcelain")
+	out, err := runOutput(cmd)
 	if err != nil {
 		return false
 	}
@@ -58,7 +261,7 @@ func WorktreeExists(path string) bool {
 		return false
 	}
 
-	lines := strings.Split(string(output), "\n")
+	lines := strings.Split(string(out), "\n")
 	for _, line := range lines {
 		if strings.HasPrefix(line, "worktree ") {
 			wtPath := strings.TrimPrefix(line, "worktree ")
@@ -71,11 +274,31 @@ func WorktreeExists(path string) bool {
 }
 
 // CreateWorktree は新しいworktreeを作成する
-func CreateWorktree(path, branchName, baseBranch string, force bool) error {
+// 作成先が空でないディレクトリの場合や、ブランチが他の worktree でチェックアウト済みの場合は ErrWorktreeExists、
+// ベースブランチが存在しない場合は ErrBranchNotFound の種類のエラーを返す
+// progress が nil でない場合は、git の標準エラー出力（チェックアウトの進捗など）を書き込む
+// track が true の場合、新規ブランチの上流（アップストリーム）をベースブランチに設定する
+// sparse を指定した場合は、cone モードの sparse checkout でそのディレクトリだけをチェックアウトする
+func CreateWorktree(path, branchName, baseBranch string, track, force bool, sparse []string, progress io.Writer) error {
+	if entries, err := os.ReadDir(path); err == nil && len(entries) > 0 {
+		return Errorf(ErrWorktreeExists, i18n.MsgGitPathExists, path)
+	}
+
+	branchExists := BranchExists(branchName)
+	if branchExists && !force {
+		// --force なしでは git も同じブランチを複数の worktree でチェックアウトできない
+		if wt, ok := WorktreeForBranch(branchName); ok {
+			return Errorf(ErrWorktreeExists, i18n.MsgGitBranchCheckedOut, branchName, wt.Path)
+		}
+	}
+	if !branchExists && baseBranch != "" && !revisionExists(baseBranch) {
+		return Errorf(ErrBranchNotFound, i18n.MsgGitBaseBranchNotFound, baseBranch)
+	}
+
 	// ディレクトリを作成
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("worktreeディレクトリの作成に失敗しました: %w", err)
+		return i18n.Errorf(i18n.MsgGitMkdirFailed, err)
 	}
 
 	// git worktree add コマンドを構築
@@ -84,31 +307,127 @@ func CreateWorktree(path, branchName, baseBranch string, force bool) error {
 	if force {
 		args = append(args, "--force")
 	}
+	if len(sparse) > 0 {
+		args = append(args, "--no-checkout")
+	}
 
 	args = append(args, path)
 
-	if BranchExists(branchName) {
+	if branchExists {
 		// 既存ブランチをチェックアウト
 		args = append(args, branchName)
 	} else {
-		// 新規ブランチを作成
+		// 新規ブランチを作成（track の場合はベースブランチを上流に設定する）
+		if track {
+			args = append(args, "--track")
+		}
 		args = append(args, "-b", branchName)
 		if baseBranch != "" {
 			args = append(args, baseBranch)
 		}
 	}
 
-	cmd := exec.Command("git", args...)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+	cmd := gitCommand(args...)
+	if progress != nil {
+		cmd.Stderr = progress
+	}
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitWorktreeAddFailed, err)
+	}
+
+	if len(sparse) > 0 {
+		if err := sparseCheckout(path, sparse, progress); err != nil {
+			// チェックアウトされていない worktree と、作成したブランチを残さない
+			RemoveWorktree(path, true)
+			if !branchExists {
+				DeleteBranch(branchName, true)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateDetachedWorktree はブランチを作成せずに、リビジョン（コミットやタグ）を detached HEAD でチェックアウトした worktree を作成する
+// 作成先が空でないディレクトリの場合は ErrWorktreeExists、リビジョンが存在しない場合は ErrBranchNotFound の種類のエラーを返す
+// progress が nil でない場合は、git の標準エラー出力を書き込む
+// sparse を指定した場合は、cone モードの sparse checkout でそのディレクトリだけをチェックアウトする
+func CreateDetachedWorktree(path, revision string, force bool, sparse []string, progress io.Writer) error {
+	if entries, err := os.ReadDir(path); err == nil && len(entries) > 0 {
+		return Errorf(ErrWorktreeExists, i18n.MsgGitPathExists, path)
+	}
+	if !revisionExists(revision) {
+		return Errorf(ErrBranchNotFound, i18n.MsgGitRevisionNotFound, revision)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return i18n.Errorf(i18n.MsgGitMkdirFailed, err)
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("worktreeの作成に失敗しました: %s", stderr.String())
+	args := []string{"worktree", "add", "--detach"}
+	if force {
+		args = append(args, "--force")
+	}
+	if len(sparse) > 0 {
+		args = append(args, "--no-checkout")
+	}
+	args = append(args, path, revision)
+
+	cmd := gitCommand(args...)
+	if progress != nil {
+		cmd.Stderr = progress
+	}
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitWorktreeAddFailed, err)
+	}
+
+	if len(sparse) > 0 {
+		if err := sparseCheckout(path, sparse, progress); err != nil {
+			RemoveWorktree(path, true)
+			return err
+		}
+	}
+	return nil
+}
+
+// sparseCheckout は --no-checkout で作成した worktree に cone モードの sparse checkout を設定してからチェックアウトする
+// 設定は worktree ごと（extensions.worktreeConfig）に保存されるため、メインworktreeや他の worktree には影響しない
+func sparseCheckout(path string, sparse []string, progress io.Writer) error {
+	args := append([]string{"-C", path, "sparse-checkout", "set", "--cone", "--"}, sparse...)
+	if err := run(gitCommand(args...)); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitSparseCheckoutFailed, err)
 	}
 
+	cmd := gitCommand("-C", path, "checkout")
+	if progress != nil {
+		cmd.Stderr = progress
+	}
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitSparseCheckoutFailed, err)
+	}
 	return nil
 }
 
+// WorktreeForBranch はブランチをチェックアウトしている worktree を返す
+func WorktreeForBranch(branchName string) (WorktreeInfo, bool) {
+	worktrees, err := ListWorktrees()
+	if err != nil {
+		return WorktreeInfo{}, false
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == branchName {
+			return wt, true
+		}
+	}
+	return WorktreeInfo{}, false
+}
+
+// revisionExists はブランチ名やコミットなどのリビジョンがコミットとして解決できるかどうかを返す
+func revisionExists(revision string) bool {
+	cmd := gitCommand("rev-parse", "--verify", "--quiet", revision+"^{commit}")
+	return run(cmd) == nil
+}
+
 // RemoveWorktree はworktreeを削除する
 func RemoveWorktree(path string, force bool) error {
 	args := []string{"worktree", "remove"}
@@ -117,30 +436,159 @@ func RemoveWorktree(path string, force bool) error {
 	}
 	args = append(args, path)
 
-	cmd := exec.Command("git", args...)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+	cmd := gitCommand(args...)
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitWorktreeRemoveFailed, err)
+	}
+
+	return nil
+}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("worktreeの削除に失敗しました: %s", stderr.String())
+// HasSubmodules は worktree に .gitmodules があるかどうかを返す
+func HasSubmodules(worktreePath string) bool {
+	_, err := os.Stat(filepath.Join(worktreePath, ".gitmodules"))
+	return err == nil
+}
+
+// HasInitializedSubmodules は worktree に初期化済みのサブモジュールがあるかどうかを返す
+// git worktree remove は初期化済みのサブモジュールを含む worktree を --force なしでは削除しない
+func HasInitializedSubmodules(worktreePath string) bool {
+	if !HasSubmodules(worktreePath) {
+		return false
 	}
+	cmd := gitCommand("-C", worktreePath, "submodule", "status")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		// 初期化されていないサブモジュールは先頭が - になる
+		if line != "" && !strings.HasPrefix(line, "-") {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateSubmodules は worktree のサブモジュールを（入れ子のものも含めて）初期化してチェックアウトする
+// reference が true の場合、メインworktreeで初期化済みのサブモジュールはそのリポジトリを --reference に指定し、
+// オブジェクトを共有して再ダウンロードを避ける
+// progress が nil でない場合は、git の標準エラー出力（クローンの進捗など）を書き込む
+func UpdateSubmodules(worktreePath string, reference bool, progress io.Writer) error {
+	if reference {
+		commonDir, err := GetCommonDir()
+		if err != nil {
+			return err
+		}
+		submodules, err := listSubmodules(worktreePath)
+		if err != nil {
+			return err
+		}
+		for name, path := range submodules {
+			// メインworktreeのサブモジュールのリポジトリは .git/modules/<name> にある
+			modulesDir := filepath.Join(commonDir, "modules", name)
+			if _, err := os.Stat(modulesDir); err != nil {
+				continue
+			}
+			cmd := gitCommand("-C", worktreePath, "submodule", "update", "--init", "--reference", modulesDir, "--", path)
+			if progress != nil {
+				cmd.Stderr = progress
+			}
+			if err := run(cmd); err != nil {
+				return commandFailed(ErrGitFailed, i18n.MsgGitSubmoduleFailed, err)
+			}
+		}
+	}
+
+	// 参照できなかったサブモジュールと、入れ子のサブモジュールを取得する
+	cmd := gitCommand("-C", worktreePath, "submodule", "update", "--init", "--recursive")
+	if progress != nil {
+		cmd.Stderr = progress
+	}
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitSubmoduleFailed, err)
+	}
+	return nil
+}
+
+// listSubmodules は worktree の .gitmodules に定義されたサブモジュールの名前とパスの対応を返す
+func listSubmodules(worktreePath string) (map[string]string, error) {
+	cmd := gitCommand("-C", worktreePath, "config", "--file", ".gitmodules", "--get-regexp", `^submodule\..*\.path$`)
+	out, err := runOutput(cmd)
+	if err != nil {
+		// 該当するキーがない場合は終了コード 1 になる
+		var cmdErr *CommandError
+		if errors.As(err, &cmdErr) && cmdErr.ExitCode == 1 {
+			return nil, nil
+		}
+		return nil, commandFailed(ErrGitFailed, i18n.MsgGitSubmoduleFailed, err)
+	}
+
+	submodules := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		key, path, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "submodule."), ".path")
+		submodules[name] = path
+	}
+	return submodules, nil
+}
+
+// UsesLFS は worktree の .gitattributes で Git LFS のフィルタを使用しているかどうかを返す
+// sparse checkout でチェックアウトされていない .gitattributes は確認しない
+func UsesLFS(worktreePath string) bool {
+	cmd := gitCommand("-C", worktreePath, "ls-files", "-z", "--", ".gitattributes", "*/.gitattributes")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return false
+	}
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(worktreePath, name))
+		if err == nil && strings.Contains(string(data), "filter=lfs") {
+			return true
+		}
+	}
+	return false
+}
 
+// LFSAvailable は git-lfs がインストールされているかどうかを返す
+func LFSAvailable() bool {
+	return run(gitCommand("lfs", "version")) == nil
+}
+
+// LFSPull は worktree の Git LFS のファイルを取得してチェックアウトする
+// LFS のオブジェクトは Gitの共通ディレクトリに保存されるため、他の worktree で取得済みのものは再ダウンロードしない
+func LFSPull(worktreePath string, progress io.Writer) error {
+	cmd := gitCommand("-C", worktreePath, "lfs", "pull")
+	if progress != nil {
+		cmd.Stderr = progress
+	}
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitLFSPullFailed, err)
+	}
 	return nil
 }
 
 // DeleteBranch はブランチを削除する
+// ブランチが存在しない場合は ErrBranchNotFound の種類のエラーを返す
 func DeleteBranch(branchName string, force bool) error {
 	flag := "-d"
 	if force {
 		flag = "-D"
 	}
 
-	cmd := exec.Command("git", "branch", flag, branchName)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+	if !BranchExists(branchName) {
+		return Errorf(ErrBranchNotFound, i18n.MsgGitBranchNotFound, branchName)
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("ブランチの削除に失敗しました: %s", stderr.String())
+	cmd := gitCommand("branch", flag, branchName)
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitBranchDeleteFailed, err)
 	}
 
 	return nil
@@ -148,16 +596,16 @@ func DeleteBranch(branchName string, force bool) error {
 
 // ListWorktrees はすべてのworktreeをリストアップする
 func ListWorktrees() ([]WorktreeInfo, error) {
-	cmd := exec.Command("git", "worktree", "list", "--porcelain")
-	output, err := cmd.Output()
+	cmd := gitCommand("worktree", "list", "--porcelain")
+	out, err := runOutput(cmd)
 	if err != nil {
-		return nil, fmt.Errorf("worktreeのリスト取得に失敗しました: %w", err)
+		return nil, commandFailed(ErrGitFailed, i18n.MsgGitWorktreeListFailed, err)
 	}
 
 	var worktrees []WorktreeInfo
 	var current *WorktreeInfo
 
-	lines := strings.Split(string(output), "\n")
+	lines := strings.Split(string(out), "\n")
 	for _, line := range lines {
 		if strings.HasPrefix(line, "worktree ") {
 			if current != nil {
@@ -166,6 +614,12 @@ func ListWorktrees() ([]WorktreeInfo, error) {
 			current = &WorktreeInfo{
 				Path: strings.TrimPrefix(line, "worktree "),
 			}
+		} else if strings.HasPrefix(line, "HEAD ") && current != nil {
+			current.Head = strings.TrimPrefix(line, "HEAD ")
+		} else if line == "detached" && current != nil {
+			current.Detached = true
+		} else if (line == "locked" || strings.HasPrefix(line, "locked ")) && current != nil {
+			current.Locked = true
 		} else if strings.HasPrefix(line, "branch ") && current != nil {
 			branch := strings.TrimPrefix(line, "branch refs/heads/")
 			current.Branch = branch
@@ -183,30 +637,272 @@ func ListWorktrees() ([]WorktreeInfo, error) {
 
 // WorktreeInfo はworktreeの情報を保持する
 type WorktreeInfo struct {
-	Path   string
-	Branch string
-	IsBare bool
+	Path     string
+	Branch   string
+	Head     string
+	IsBare   bool
+	Detached bool
+	Locked   bool
 }
 
 // HasUncommittedChanges は未コミットの変更があるかどうかを確認する
 func HasUncommittedChanges(worktreePath string) (bool, error) {
-	cmd := exec.Command("git", "-C", worktreePath, "status", "--porcelain")
-	output, err := cmd.Output()
+	cmd := gitCommand("-C", worktreePath, "status", "--porcelain")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return false, commandFailed(ErrGitFailed, i18n.MsgGitStatusFailed, err)
+	}
+	return len(strings.TrimSpace(string(out))) > 0, nil
+}
+
+// HasTrackedChanges は追跡しているファイルに未コミットの変更があるかどうかを確認する
+// 未追跡のファイルは、マージなどで上書きされる場合は git が中止するため含めない
+func HasTrackedChanges(worktreePath string) (bool, error) {
+	cmd := gitCommand("-C", worktreePath, "status", "--porcelain", "--untracked-files=no")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return false, commandFailed(ErrGitFailed, i18n.MsgGitStatusFailed, err)
+	}
+	return len(strings.TrimSpace(string(out))) > 0, nil
+}
+
+// SparsePaths は worktree の sparse checkout でチェックアウトしているディレクトリを返す
+// sparse checkout でない場合は nil を返す
+func SparsePaths(worktreePath string) []string {
+	cmd := gitCommand("-C", worktreePath, "sparse-checkout", "list")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(out))
+}
+
+// Status は worktree のブランチの状態と変更されたファイルを短い形式で返す
+func Status(worktreePath string) (string, error) {
+	cmd := gitCommand("-C", worktreePath, "status", "--short", "--branch")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return "", commandFailed(ErrGitFailed, i18n.MsgGitStatusFailed, err)
+	}
+	return string(out), nil
+}
+
+// ChangedFiles は worktree 内の未コミットの変更があるファイル数を返す
+func ChangedFiles(worktreePath string) (int, error) {
+	cmd := gitCommand("-C", worktreePath, "status", "--porcelain")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return 0, commandFailed(ErrGitFailed, i18n.MsgGitStatusFailed, err)
+	}
+
+	count := 0
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// AheadBehind は worktree のブランチが上流ブランチより進んでいるコミット数と遅れているコミット数を返す
+// 上流ブランチが設定されていない場合は ok に false を返す
+func AheadBehind(worktreePath string) (ahead, behind int, ok bool) {
+	cmd := gitCommand("-C", worktreePath, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	out, err := runOutput(cmd)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	ahead, err1 := strconv.Atoi(fields[0])
+	behind, err2 := strconv.Atoi(fields[1])
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
+}
+
+// IsAncestor は revision が worktree の HEAD に含まれている（HEAD の祖先または HEAD 自身である）かどうかを返す
+func IsAncestor(worktreePath, revision string) bool {
+	cmd := gitCommand("-C", worktreePath, "merge-base", "--is-ancestor", revision, "HEAD")
+	return run(cmd) == nil
+}
+
+// Rebase は worktree のブランチを onto の上にリベースする
+// 衝突した場合はリベースを中止して元の状態に戻し、ErrConflict の種類のエラーを返す
+func Rebase(worktreePath, onto string) error {
+	cmd := gitCommand("-C", worktreePath, "rebase", onto)
+	if err := run(cmd); err != nil {
+		return abortOnConflict(worktreePath, i18n.MsgGitRebaseFailed, err, "rebase", "--abort")
+	}
+	return nil
+}
+
+// Merge は worktree のブランチに revision をマージする
+// 衝突した場合はマージを中止して元の状態に戻し、ErrConflict の種類のエラーを返す
+func Merge(worktreePath, revision string) error {
+	cmd := gitCommand("-C", worktreePath, "merge", "--no-edit", revision)
+	if err := run(cmd); err != nil {
+		return abortOnConflict(worktreePath, i18n.MsgGitMergeFailed, err, "merge", "--abort")
+	}
+	return nil
+}
+
+// MergeFastForward は worktree のブランチを revision まで早送り（fast-forward）する
+// 早送りできない場合は何も変更せず、ErrGitFailed の種類のエラーを返す
+func MergeFastForward(worktreePath, revision string) error {
+	cmd := gitCommand("-C", worktreePath, "merge", "--ff-only", revision)
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitFastForwardFailed, err)
+	}
+	return nil
+}
+
+// MergeSquash は revision の変更を1つのコミットにまとめて worktree のブランチにコミットする
+// コミットメッセージは git が生成する（まとめたコミットの一覧）
+// 衝突した場合は中止して元の状態に戻し、ErrConflict の種類のエラーを返す
+// 変更がすでに取り込まれていて差分がない場合は、元の状態に戻して ErrNothingToSquash を返す
+func MergeSquash(worktreePath, revision string) error {
+	cmd := gitCommand("-C", worktreePath, "merge", "--squash", revision)
+	if err := run(cmd); err != nil {
+		// --squash では MERGE_HEAD が作られず merge --abort で中止できないため、reset --merge で戻す
+		return abortOnConflict(worktreePath, i18n.MsgGitMergeFailed, err, "reset", "--merge")
+	}
+	// 祖先でなくても、チェリーピックなどで同じ変更が取り込まれている場合はコミットするものがない
+	cmd = gitCommand("-C", worktreePath, "diff", "--cached", "--quiet")
+	if err := run(cmd); err == nil {
+		run(gitCommand("-C", worktreePath, "reset", "--merge"))
+		return ErrNothingToSquash
+	}
+	cmd = gitCommand("-C", worktreePath, "commit", "--no-edit")
+	if err := run(cmd); err != nil {
+		run(gitCommand("-C", worktreePath, "reset", "--merge"))
+		return commandFailed(ErrGitFailed, i18n.MsgGitCommitFailed, err)
+	}
+	return nil
+}
+
+// abortOnConflict は失敗したリベースやマージを abort のコマンドで中止し、worktree を実行前の状態に戻す
+// 衝突したファイルがあった場合は ErrConflict、それ以外は ErrGitFailed の種類のエラーを返す
+func abortOnConflict(worktreePath string, id i18n.MessageID, err error, abort ...string) error {
+	kind := ErrGitFailed
+	cmd := gitCommand("-C", worktreePath, "diff", "--name-only", "--diff-filter=U")
+	if out, diffErr := runOutput(cmd); diffErr == nil && strings.TrimSpace(string(out)) != "" {
+		kind = ErrConflict
+	}
+
+	// 開始前に失敗した場合は中止するものがないため、エラーは無視する
+	run(gitCommand(append([]string{"-C", worktreePath}, abort...)...))
+	return commandFailed(kind, id, err)
+}
+
+// Diff は worktree の HEAD からの差分（ステージ済みの変更を含む）を返す
+func Diff(worktreePath string) (string, error) {
+	cmd := gitCommand("-C", worktreePath, "diff", "--no-color", "HEAD")
+	out, err := runOutput(cmd)
 	if err != nil {
-		return false, fmt.Errorf("ステータスの確認に失敗しました: %w", err)
+		return "", commandFailed(ErrGitFailed, i18n.MsgGitDiffFailed, err)
 	}
-	return len(strings.TrimSpace(string(output))) > 0, nil
+	return string(out), nil
+}
+
+// LockWorktree は worktree をロックし、git worktree prune などで削除されないようにする
+func LockWorktree(path, reason string) error {
+	args := []string{"worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	args = append(args, path)
+
+	cmd := gitCommand(args...)
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitLockFailed, err)
+	}
+	return nil
+}
+
+// UnlockWorktree は worktree のロックを解除する
+func UnlockWorktree(path string) error {
+	cmd := gitCommand("worktree", "unlock", path)
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitUnlockFailed, err)
+	}
+	return nil
 }
 
 // Fetch はリモートから最新の情報を取得する
-func Fetch(remote string) error {
-	cmd := exec.Command("git", "fetch", remote)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+// progress が nil でない場合は、git の進捗の出力を書き込む
+func Fetch(remote string, progress io.Writer) error {
+	cmd := gitCommand("fetch", remote)
+	if progress != nil {
+		cmd = gitCommand("fetch", "--progress", remote)
+		cmd.Stderr = progress
+	}
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitFetchFailed, err)
+	}
+
+	return nil
+}
+
+// Push は worktree のブランチをリモートの同名ブランチに push する
+// setUpstream が true の場合は push 先を上流に設定する（git push -u）
+// progress が nil でない場合は、git の進捗の出力を書き込む
+func Push(worktreePath, remote, branchName string, setUpstream bool, progress io.Writer) error {
+	args := []string{"-C", worktreePath, "push"}
+	if progress != nil {
+		args = append(args, "--progress")
+	}
+	if setUpstream {
+		args = append(args, "--set-upstream")
+	}
+	args = append(args, remote, "refs/heads/"+branchName+":refs/heads/"+branchName)
+
+	cmd := gitCommand(args...)
+	if progress != nil {
+		cmd.Stderr = progress
+	}
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitPushFailed, err)
+	}
+	return nil
+}
+
+// SetUpstream はブランチの上流を remote の upstreamBranch に設定する
+// git branch --set-upstream-to と異なり、リモートにまだ存在しないブランチも設定できる
+// remote に "." を指定するとローカルのブランチを上流にする
+func SetUpstream(branchName, remote, upstreamBranch string) error {
+	for _, kv := range [][2]string{
+		{"branch." + branchName + ".remote", remote},
+		{"branch." + branchName + ".merge", "refs/heads/" + upstreamBranch},
+	} {
+		cmd := gitCommand("config", kv[0], kv[1])
+		if err := run(cmd); err != nil {
+			return commandFailed(ErrGitFailed, i18n.MsgGitSetUpstreamFailed, err)
+		}
+	}
+	return nil
+}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("fetchに失敗しました: %s", stderr.String())
+// FetchRef はリモートの ref を取得し、ローカルの ref に書き込む
+// プルリクエストの refs/pull/<番号>/head など、通常の fetch では取得されない ref に使用する
+// 取得し直した場合に履歴が書き換えられていても更新できるよう、強制的に上書きする
+func FetchRef(remote, src, dst string, progress io.Writer) error {
+	args := []string{"fetch"}
+	if progress != nil {
+		args = append(args, "--progress")
 	}
+	args = append(args, remote, "+"+src+":"+dst)
 
+	cmd := gitCommand(args...)
+	if progress != nil {
+		cmd.Stderr = progress
+	}
+	if err := run(cmd); err != nil {
+		return commandFailed(ErrGitFailed, i18n.MsgGitFetchFailed, err)
+	}
 	return nil
 }
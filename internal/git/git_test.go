@@ -1,9 +1,11 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -189,3 +191,669 @@ func TestHasUncommittedChanges(t *testing.T) {
 		t.Error("expected uncommitted changes after modifying file")
 	}
 }
+
+func TestHasTrackedChanges(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	// 未追跡のファイルは含めない
+	if err := os.WriteFile(filepath.Join(tmpDir, "untracked.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to create untracked file: %v", err)
+	}
+	if hasChanges, err := HasTrackedChanges(tmpDir); err != nil || hasChanges {
+		t.Errorf("expected no tracked changes, got %v, %v", hasChanges, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("modified content"), 0644); err != nil {
+		t.Fatalf("failed to modify test file: %v", err)
+	}
+	if hasChanges, err := HasTrackedChanges(tmpDir); err != nil || !hasChanges {
+		t.Errorf("expected tracked changes, got %v, %v", hasChanges, err)
+	}
+}
+
+func TestChangedFilesAndDiff(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	count, err := ChangedFiles(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to count changed files: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected no changed files, got %d", count)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("modified content"), 0644); err != nil {
+		t.Fatalf("failed to modify test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	count, err = ChangedFiles(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to count changed files: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 changed files, got %d", count)
+	}
+
+	diff, err := Diff(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to get diff: %v", err)
+	}
+	if !strings.Contains(diff, "+modified content") {
+		t.Errorf("expected diff to contain the modification, got:\n%s", diff)
+	}
+}
+
+func TestAheadBehindWithoutUpstream(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	if _, _, ok := AheadBehind(tmpDir); ok {
+		t.Error("expected no upstream in a fresh repository")
+	}
+}
+
+func TestListBranchesAndRemotes(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+
+	// リモート追跡ブランチとデフォルトブランチへの参照を作成
+	for _, args := range [][]string{
+		{"branch", "feature/a"},
+		{"remote", "add", "upstream", "https://example.com/repo.git"},
+		{"update-ref", "refs/remotes/upstream/main", "HEAD"},
+		{"symbolic-ref", "refs/remotes/upstream/HEAD", "refs/remotes/upstream/main"},
+	} {
+		if err := exec.Command("git", args...).Run(); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+
+	local, err := ListBranches(false)
+	if err != nil {
+		t.Fatalf("failed to list branches: %v", err)
+	}
+	if !strings.Contains(strings.Join(local, " "), "feature/a") || strings.Contains(strings.Join(local, " "), "upstream/") {
+		t.Errorf("unexpected local branches: %v", local)
+	}
+
+	all, err := ListBranches(true)
+	if err != nil {
+		t.Fatalf("failed to list branches: %v", err)
+	}
+	if got := strings.Join(all, " "); !strings.Contains(got, "upstream/main") || strings.Contains(got, "HEAD") {
+		t.Errorf("unexpected branches: %v", all)
+	}
+
+	remotes, err := ListRemotes()
+	if err != nil {
+		t.Fatalf("failed to list remotes: %v", err)
+	}
+	if len(remotes) != 1 || remotes[0] != "upstream" {
+		t.Errorf("unexpected remotes: %v", remotes)
+	}
+}
+
+func TestErrorKinds(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+
+	current, err := GetCurrentBranch()
+	if err != nil {
+		t.Fatalf("failed to get current branch: %v", err)
+	}
+
+	// チェックアウト済みのブランチ
+	err = CreateWorktree(filepath.Join(t.TempDir(), "wt"), current, "", false, false, nil, nil)
+	if !errors.Is(err, ErrWorktreeExists) {
+		t.Errorf("expected ErrWorktreeExists, got %v", err)
+	}
+
+	// 存在しないベースブランチ
+	err = CreateWorktree(filepath.Join(t.TempDir(), "wt"), "feature/new", "no-such-base", false, false, nil, nil)
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("expected ErrBranchNotFound, got %v", err)
+	}
+
+	// 存在しないブランチの削除
+	err = DeleteBranch("no-such-branch", false)
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("expected ErrBranchNotFound, got %v", err)
+	}
+
+	// git コマンドの失敗は終了コードと標準エラー出力を保持する
+	_, err = Diff(t.TempDir())
+	if !errors.Is(err, ErrGitFailed) {
+		t.Errorf("expected ErrGitFailed, got %v", err)
+	}
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected CommandError, got %T", err)
+	}
+	if cmdErr.ExitCode == 0 || cmdErr.Stderr == "" {
+		t.Errorf("unexpected command error: %+v", cmdErr)
+	}
+}
+
+func TestRemoteBranchesAndPullRequests(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+	remoteDir := filepath.Join(t.TempDir(), "remote.git")
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+
+	// ローカルのbareリポジトリにブランチとプルリクエストの ref を作成する
+	for _, args := range [][]string{
+		{"init", "--bare", remoteDir},
+		{"remote", "add", "origin", remoteDir},
+		{"push", "origin", "HEAD:refs/heads/feature/x", "HEAD:refs/pull/7/head"},
+		{"fetch", "origin"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	remote, branch, ok := SplitRemoteBranch("origin/feature/x")
+	if !ok || remote != "origin" || branch != "feature/x" {
+		t.Errorf("unexpected split: %q, %q, %v", remote, branch, ok)
+	}
+	if _, _, ok := SplitRemoteBranch("unknown/feature/x"); ok {
+		t.Error("expected unknown remote not to be split")
+	}
+
+	if !RemoteBranchExists("origin", "feature/x") || RemoteBranchExists("origin", "feature/y") {
+		t.Error("unexpected remote branch existence")
+	}
+
+	// 追跡ブランチの作成
+	wtPath := filepath.Join(t.TempDir(), "x")
+	if err := CreateWorktree(wtPath, "feature/x", "refs/remotes/origin/feature/x", true, false, nil, nil); err != nil {
+		t.Fatalf("failed to create tracking worktree: %v", err)
+	}
+	out, err := exec.Command("git", "-C", wtPath, "rev-parse", "--abbrev-ref", "@{upstream}").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "origin/feature/x" {
+		t.Errorf("unexpected upstream: %q, %v", out, err)
+	}
+
+	// プルリクエストの ref の取得
+	if err := FetchRef("origin", "refs/pull/7/head", "refs/scion/pr/origin/7", nil); err != nil {
+		t.Fatalf("failed to fetch pull request: %v", err)
+	}
+	if !revisionExists("refs/scion/pr/origin/7") {
+		t.Error("expected pull request ref to be fetched")
+	}
+	if err := FetchRef("origin", "refs/pull/8/head", "refs/scion/pr/origin/8", nil); !errors.Is(err, ErrGitFailed) {
+		t.Errorf("expected ErrGitFailed for missing pull request, got %v", err)
+	}
+}
+
+func TestCreateDetachedWorktree(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	if err := exec.Command("git", "tag", "v1.0").Run(); err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+
+	wtPath := filepath.Join(t.TempDir(), "v1.0")
+	if err := CreateDetachedWorktree(wtPath, "v1.0", false, nil, nil); err != nil {
+		t.Fatalf("failed to create detached worktree: %v", err)
+	}
+	err = CreateDetachedWorktree(filepath.Join(t.TempDir(), "x"), "no-such-tag", false, nil, nil)
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("expected ErrBranchNotFound, got %v", err)
+	}
+
+	worktrees, err := ListWorktrees()
+	if err != nil {
+		t.Fatalf("failed to list worktrees: %v", err)
+	}
+	if len(worktrees) != 2 {
+		t.Fatalf("expected 2 worktrees, got %d", len(worktrees))
+	}
+	wt := worktrees[1]
+	if !wt.Detached || wt.Branch != "" || wt.Head != worktrees[0].Head {
+		t.Errorf("unexpected detached worktree: %+v", wt)
+	}
+
+	tags, err := ListTags()
+	if err != nil || len(tags) != 1 || tags[0] != "v1.0" {
+		t.Errorf("unexpected tags: %v, %v", tags, err)
+	}
+}
+
+func TestPushAndSetUpstream(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+	remoteDir := filepath.Join(t.TempDir(), "remote.git")
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init", "--bare", remoteDir},
+		{"remote", "add", "origin", remoteDir},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	wtPath := filepath.Join(t.TempDir(), "a")
+	if err := CreateWorktree(wtPath, "feature/a", "", false, false, nil, nil); err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+
+	// push 前でも上流を設定できる
+	if err := SetUpstream("feature/a", "origin", "feature/a"); err != nil {
+		t.Fatalf("failed to set upstream: %v", err)
+	}
+	out, err := exec.Command("git", "config", "branch.feature/a.merge").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "refs/heads/feature/a" {
+		t.Errorf("unexpected merge config: %q, %v", out, err)
+	}
+
+	if err := Push(wtPath, "origin", "feature/a", true, nil); err != nil {
+		t.Fatalf("failed to push: %v", err)
+	}
+	if !RemoteBranchExists("origin", "feature/a") {
+		t.Error("expected remote branch to exist after push")
+	}
+	if ahead, behind, ok := AheadBehind(wtPath); !ok || ahead != 0 || behind != 0 {
+		t.Errorf("unexpected ahead/behind: %d, %d, %v", ahead, behind, ok)
+	}
+
+	if err := Push(wtPath, "no-such-remote", "feature/a", false, nil); !errors.Is(err, ErrGitFailed) {
+		t.Errorf("expected ErrGitFailed, got %v", err)
+	}
+}
+
+func TestRebaseAndMerge(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	base, err := GetCurrentBranch()
+	if err != nil {
+		t.Fatalf("failed to get current branch: %v", err)
+	}
+
+	commit := func(dir, file, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		for _, args := range [][]string{{"add", "."}, {"commit", "-m", "update " + file}} {
+			if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+		}
+	}
+
+	wtA := filepath.Join(t.TempDir(), "a")
+	wtB := filepath.Join(t.TempDir(), "b")
+	for path, branch := range map[string]string{wtA: "feature/a", wtB: "feature/b"} {
+		if err := CreateWorktree(path, branch, "", false, false, nil, nil); err != nil {
+			t.Fatalf("failed to create worktree: %v", err)
+		}
+	}
+	commit(wtA, "a.txt", "a")
+	commit(wtB, "test.txt", "b")
+	commit(tmpDir, "test.txt", "base")
+
+	if IsAncestor(wtA, base) {
+		t.Fatal("expected base not to be merged yet")
+	}
+	if err := Rebase(wtA, base); err != nil {
+		t.Fatalf("failed to rebase: %v", err)
+	}
+	if !IsAncestor(wtA, base) {
+		t.Error("expected base to be an ancestor after rebase")
+	}
+
+	commit(tmpDir, "base.txt", "base")
+	if err := Merge(wtA, base); err != nil {
+		t.Fatalf("failed to merge: %v", err)
+	}
+	if !IsAncestor(wtA, base) {
+		t.Error("expected base to be an ancestor after merge")
+	}
+
+	// 衝突した場合は中止して元の状態に戻す
+	head, _ := HeadCommit(wtB)
+	if err := Rebase(wtB, base); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict from rebase, got %v", err)
+	}
+	if err := Merge(wtB, base); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict from merge, got %v", err)
+	}
+	if after, _ := HeadCommit(wtB); after != head {
+		t.Errorf("expected HEAD to be restored, got %s, want %s", after, head)
+	}
+	if dirty, err := HasUncommittedChanges(wtB); err != nil || dirty {
+		t.Errorf("expected clean worktree after abort, got %v, %v", dirty, err)
+	}
+
+	if err := Rebase(wtA, "no-such-branch"); !errors.Is(err, ErrGitFailed) {
+		t.Errorf("expected ErrGitFailed, got %v", err)
+	}
+}
+
+func TestMergeSquashAndFastForward(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+
+	commit := func(dir, file, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		for _, args := range [][]string{{"add", "."}, {"commit", "-m", "update " + file}} {
+			if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+		}
+	}
+
+	wtPath := filepath.Join(t.TempDir(), "a")
+	if err := CreateWorktree(wtPath, "feature/a", "", false, false, nil, nil); err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+	wt, ok := WorktreeForBranch("feature/a")
+	if !ok || filepath.Base(wt.Path) != "a" {
+		t.Errorf("unexpected worktree for branch: %+v, %v", wt, ok)
+	}
+	commit(wtPath, "a.txt", "a")
+	commit(wtPath, "b.txt", "b")
+
+	if err := MergeFastForward(tmpDir, "feature/a"); err != nil {
+		t.Fatalf("failed to fast-forward: %v", err)
+	}
+	if !IsAncestor(tmpDir, "feature/a") {
+		t.Error("expected branch to be merged after fast-forward")
+	}
+
+	// 早送りできない場合は何も変更しない
+	commit(wtPath, "c.txt", "c")
+	commit(tmpDir, "test.txt", "base")
+	head, _ := HeadCommit(tmpDir)
+	if err := MergeFastForward(tmpDir, "feature/a"); !errors.Is(err, ErrGitFailed) {
+		t.Errorf("expected ErrGitFailed, got %v", err)
+	}
+
+	if err := MergeSquash(tmpDir, "feature/a"); err != nil {
+		t.Fatalf("failed to squash: %v", err)
+	}
+	out, err := exec.Command("git", "rev-list", "--count", head+"..HEAD").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "1" {
+		t.Errorf("expected a single squashed commit, got %q, %v", out, err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "c.txt")); err != nil {
+		t.Errorf("expected squashed changes to be committed: %v", err)
+	}
+
+	// まとめた変更がすでに取り込まれている場合はコミットしない
+	head, _ = HeadCommit(tmpDir)
+	if err := MergeSquash(tmpDir, "feature/a"); !errors.Is(err, ErrNothingToSquash) {
+		t.Errorf("expected ErrNothingToSquash, got %v", err)
+	}
+	if after, _ := HeadCommit(tmpDir); after != head {
+		t.Errorf("expected HEAD to be unchanged, got %s, want %s", after, head)
+	}
+	if dirty, err := HasUncommittedChanges(tmpDir); err != nil || dirty {
+		t.Errorf("expected clean worktree, got %v, %v", dirty, err)
+	}
+
+	// 衝突した場合は中止して元の状態に戻す
+	commit(wtPath, "test.txt", "branch")
+	head, _ = HeadCommit(tmpDir)
+	if err := MergeSquash(tmpDir, "feature/a"); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+	if after, _ := HeadCommit(tmpDir); after != head {
+		t.Errorf("expected HEAD to be unchanged, got %s, want %s", after, head)
+	}
+	if dirty, err := HasUncommittedChanges(tmpDir); err != nil || dirty {
+		t.Errorf("expected clean worktree after abort, got %v, %v", dirty, err)
+	}
+}
+
+func TestCreateSparseWorktree(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	for _, dir := range []string{"services/api", "services/web"} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	for _, args := range [][]string{{"add", "."}, {"commit", "-m", "add services"}} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	wtPath := filepath.Join(t.TempDir(), "api")
+	if err := CreateWorktree(wtPath, "feature/api", "", false, false, []string{"services/api"}, nil); err != nil {
+		t.Fatalf("failed to create sparse worktree: %v", err)
+	}
+	// cone モードでは指定したディレクトリとルート直下のファイルだけをチェックアウトする
+	for path, want := range map[string]bool{"services/api/main.go": true, "test.txt": true, "services/web": false} {
+		if _, err := os.Stat(filepath.Join(wtPath, path)); (err == nil) != want {
+			t.Errorf("unexpected existence of %s: %v", path, err)
+		}
+	}
+	if dirty, err := HasUncommittedChanges(wtPath); err != nil || dirty {
+		t.Errorf("expected clean sparse worktree, got %v, %v", dirty, err)
+	}
+	if paths := SparsePaths(wtPath); len(paths) != 1 || paths[0] != "services/api" {
+		t.Errorf("unexpected sparse paths: %v", paths)
+	}
+
+	// メインworktreeには影響しない
+	if paths := SparsePaths(tmpDir); paths != nil {
+		t.Errorf("expected main worktree not to be sparse, got %v", paths)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "services", "web", "main.go")); err != nil {
+		t.Errorf("expected main worktree to keep all files: %v", err)
+	}
+
+	detachedPath := filepath.Join(t.TempDir(), "web")
+	if err := CreateDetachedWorktree(detachedPath, "HEAD", false, []string{"services/web"}, nil); err != nil {
+		t.Fatalf("failed to create sparse detached worktree: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(detachedPath, "services", "api")); !os.IsNotExist(err) {
+		t.Errorf("expected services/api not to be checked out, got %v", err)
+	}
+}
+
+func TestSubmodulesAndLFS(t *testing.T) {
+	// ローカルのリポジトリをサブモジュールとしてクローンできるようにする
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+
+	libDir := setupTestGitRepo(t)
+	tmpDir := setupTestGitRepo(t)
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	if UsesLFS(tmpDir) || HasSubmodules(tmpDir) {
+		t.Error("expected no submodules and LFS before adding them")
+	}
+	if err := os.WriteFile(".gitattributes", []byte("*.bin filter=lfs diff=lfs merge=lfs -text\n"), 0644); err != nil {
+		t.Fatalf("failed to write .gitattributes: %v", err)
+	}
+	for _, args := range [][]string{
+		{"submodule", "add", "--quiet", libDir, "libs/lib"},
+		{"add", "."},
+		{"commit", "-m", "add submodule"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	wtPath := filepath.Join(t.TempDir(), "wt")
+	if err := CreateWorktree(wtPath, "feature/sub", "", false, false, nil, nil); err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+	if !HasSubmodules(wtPath) || HasInitializedSubmodules(wtPath) {
+		t.Error("expected uninitialized submodules in new worktree")
+	}
+	if !UsesLFS(wtPath) {
+		t.Error("expected LFS attributes to be detected")
+	}
+
+	if err := UpdateSubmodules(wtPath, true, nil); err != nil {
+		t.Fatalf("failed to update submodules: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wtPath, "libs", "lib", "test.txt")); err != nil {
+		t.Errorf("expected submodule to be checked out: %v", err)
+	}
+	if !HasInitializedSubmodules(wtPath) {
+		t.Error("expected initialized submodules after update")
+	}
+
+	// メインworktreeのサブモジュールのオブジェクトを参照する
+	out, err := exec.Command("git", "-C", filepath.Join(wtPath, "libs", "lib"), "rev-parse", "--git-path", "objects/info/alternates").Output()
+	if err != nil {
+		t.Fatalf("failed to get alternates path: %v", err)
+	}
+	alternatesPath := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(alternatesPath) {
+		alternatesPath = filepath.Join(wtPath, "libs", "lib", alternatesPath)
+	}
+	alternates, err := os.ReadFile(alternatesPath)
+	if err != nil || !strings.Contains(string(alternates), filepath.Join(".git", "modules", "libs", "lib")) {
+		t.Errorf("expected submodule to reference the main worktree, got %q, %v", alternates, err)
+	}
+
+	// 初期化済みのサブモジュールを含む worktree は --force でのみ削除できる
+	if err := RemoveWorktree(wtPath, false); !errors.Is(err, ErrGitFailed) {
+		t.Errorf("expected ErrGitFailed, got %v", err)
+	}
+	if err := RemoveWorktree(wtPath, true); err != nil {
+		t.Errorf("failed to remove worktree: %v", err)
+	}
+}
+
+func TestUpdateBranch(t *testing.T) {
+	tmpDir := setupTestGitRepo(t)
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+
+	initial, err := ResolveCommit("HEAD")
+	if err != nil {
+		t.Fatalf("failed to resolve HEAD: %v", err)
+	}
+	for _, args := range [][]string{
+		{"branch", "feature/a"},
+		{"commit", "--allow-empty", "-m", "Next"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	next, _ := ResolveCommit("HEAD")
+
+	if !IsAncestorOf(initial, next) || IsAncestorOf(next, initial) {
+		t.Error("unexpected ancestry")
+	}
+	if _, err := ResolveCommit("unknown"); !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("expected ErrBranchNotFound for unknown revision, got %v", err)
+	}
+
+	// 現在の値が一致しない場合は更新しない
+	if err := UpdateBranch("feature/a", next, next); !errors.Is(err, ErrGitFailed) {
+		t.Errorf("expected ErrGitFailed for stale old value, got %v", err)
+	}
+	if err := UpdateBranch("feature/a", next, initial); err != nil {
+		t.Fatalf("failed to update branch: %v", err)
+	}
+	if got, _ := ResolveCommit("feature/a"); got != next {
+		t.Errorf("expected feature/a at %s, got %s", next, got)
+	}
+}
@@ -0,0 +1,521 @@
+package i18n
+
+// catalogEn は英語のメッセージカタログ
+// 他の言語のカタログに存在しないメッセージのフォールバックとしても使用される
+var catalogEn = map[MessageID]string{
+	// コマンドのヘルプテキスト
+	CmdRootShort: "A CLI tool for managing Git worktrees efficiently",
+	CmdRootLong: `scion is a CLI tool for managing Git worktrees efficiently.
+It provides a simple workflow designed for working with AI development
+environments such as Claude Code, GitHub Copilot and Cursor.
+
+Available commands:
+  create  - Create a new worktree branch
+  clear   - Remove an existing worktree branch
+  list    - List worktrees
+  cd      - Print the path of a worktree
+  open    - Open a worktree in the editor
+  ui      - Show the worktree dashboard
+  config  - Manage scion configuration`,
+	CmdCreateShort: "Create a new worktree branch",
+	CmdCreateLong: `The create command creates a new Git worktree branch in a dedicated directory.
+
+The worktree directory is created next to the base repository.
+
+Examples:
+  scion create feature/new-feature
+  scion create feature/payment --base develop
+  scion create bugfix/issue-123 --remote upstream
+  scion create feature/refactor --force
+  scion create --track origin/feature-x
+  scion create --pr 123`,
+	CmdClearShort: "Remove an existing worktree branch",
+	CmdClearLong: `The clear command removes an existing Git worktree branch and its directory.
+
+Examples:
+  scion clear feature/old-feature
+  scion clear feature/experimental --force
+  scion clear feature/temp --keep-branch
+  scion clear --all`,
+	CmdConfigShort: "Manage scion configuration",
+	CmdConfigLong: `The config command manages scion configuration.
+
+Subcommands:
+  get <key>      - Get a configuration value
+  set <key> <value> - Update a configuration value
+  list           - Show all configuration values
+  reset          - Reset configuration to defaults
+  edit           - Open the configuration file in an editor
+  schema         - Print the JSON Schema of the configuration file
+  migrate        - Upgrade configuration files to the current format
+
+Examples:
+  scion config get worktree.base_dir
+  scion config set ui.color_output false
+  scion config set hooks.post_create[+] "npm ci"
+  scion config list
+  scion config edit
+  scion config schema --output .scion/config.schema.json`,
+	CmdConfigGetShort:    "Get a configuration value",
+	CmdConfigSetShort:    "Update a configuration value",
+	CmdConfigListShort:   "Show all configuration values",
+	CmdConfigResetShort:  "Reset configuration to defaults",
+	CmdConfigEditShort:   "Open the configuration file in an editor",
+	CmdConfigSchemaShort: "Print the JSON Schema of the configuration file",
+	CmdConfigSchemaLong: `The schema command prints a JSON Schema generated from the Config struct.
+
+Load it into a TOML language server such as taplo to get completion and
+validation for config.toml.
+
+Examples:
+  scion config schema
+  scion config schema --output ~/.config/scion/config.schema.json`,
+	CmdConfigMigrateShort: "Upgrade configuration files to the current format",
+	CmdConfigMigrateLong: `The migrate command upgrades configuration files written by older versions of scion.
+
+The original file is kept as <file>.bak.
+Without --global / --local, every existing configuration file is migrated.
+
+Examples:
+  scion config migrate
+  scion config migrate --local
+  scion config migrate --dry-run`,
+	CmdListShort: "List worktrees",
+	CmdListLong: `The list command lists the worktrees of the repository.
+
+Use --format to print each worktree with a Go template instead of a table.
+Available fields: .Branch, .Path, .Head, .Main, .Bare, .Detached
+Available functions: json, upper, lower, join
+
+Examples:
+  scion list
+  scion list --format '{{.Branch}}\t{{.Path}}'
+  scion list --format '{{json .}}'`,
+	CmdCdShort: "Print the path of a worktree",
+	CmdCdLong: `The cd command prints the path of the worktree for a branch.
+
+A program cannot change the directory of its parent shell, so combine it with cd.
+Without a branch name, an interactive picker is shown in a terminal.
+
+Examples:
+  cd "$(scion cd feature/login)"
+  cd "$(scion cd)"
+
+Shell function:
+  scd() { cd "$(scion cd "$@")"; }`,
+	CmdOpenShort: "Open a worktree in the editor",
+	CmdOpenLong: `The open command opens the worktree for a branch in the editor.
+
+The editor is taken from $EDITOR or editor.command in the configuration.
+Without a branch name, an interactive picker is shown in a terminal.
+
+Examples:
+  scion open feature/login
+  scion open`,
+	CmdUIShort: "Show the worktree dashboard",
+	CmdUILong: `The ui command shows a full-screen dashboard of the worktrees.
+
+The number of changed files, commits ahead of and behind the upstream
+branch and running AI agents are refreshed every 2 seconds.
+Worktrees can be created, removed, locked and opened in an agent or the
+editor from the dashboard.
+
+Keys:
+  j/k, ↑/↓  move the cursor
+  c         create a worktree
+  d         remove the worktree
+  a         start the agent (agent.command) in the worktree
+  o         open the worktree in the editor
+  v         show the diff
+  l         lock or unlock the worktree
+  r         refresh
+  q         quit`,
+	CmdLogShort: "Show the history of operations",
+	CmdLogLong: `The log command shows the audit log of operations that changed worktrees
+or the configuration (create, clear, config set, config reset).
+
+Each entry records the time, user, repository, branch, path, the commit
+checked out before or after the operation and whether it succeeded.
+The log is stored in $XDG_STATE_HOME/scion/audit.jsonl
+(default: ~/.local/state/scion/audit.jsonl).
+
+Inside a repository only the operations on that repository are shown.
+
+Use --format to print each entry with a Go template instead of a table.
+Available fields: .Time, .Action, .User, .Repo, .Branch, .Path, .Before,
+.After, .Outcome, .Error, .Details
+
+Examples:
+  scion log
+  scion log --branch feature/login
+  scion log --action clear --limit 5
+  scion log --format '{{json .}}'`,
+	CmdSyncShort: "Rebase or merge worktree branches onto their base",
+	CmdSyncLong: `The sync command fetches once and then rebases (or merges) each worktree's
+branch onto the base it was created from. Worktrees created before the base
+was recorded use git.default_base_branch. When the base exists on the remote,
+the fetched remote-tracking branch is used.
+
+Worktrees with uncommitted changes or a detached HEAD are skipped. On a
+conflict the rebase or merge is aborted and the worktree is left unchanged.
+
+Examples:
+  scion sync feature/login
+  scion sync --all
+  scion sync --all --mode merge`,
+	CmdLandShort: "Merge a worktree branch into its base and optionally clear it",
+	CmdLandLong: `The land command integrates a worktree's branch into the base branch it was
+created from. The worktree must have no uncommitted changes.
+
+The branch is merged in the worktree that has the base branch checked out
+(usually the main worktree). When the base branch is not checked out anywhere,
+or its worktree has uncommitted changes, the branch is merged in a temporary
+worktree and the base branch is then moved to the result.
+
+Before landing, the hooks.pre_land commands are run in the worktree (for
+example, tests) on the commits that will land, after --rebase; landing stops
+if one of them fails. With --clear the worktree
+and its branch are removed after the branch has landed.
+
+Examples:
+  scion land feature/login
+  scion land feature/login --squash --clear
+  scion land feature/login --rebase --no-verify`,
+
+	// フラグの説明
+	FlagRootConfig:         "path to the configuration file (default: ~/.config/scion/config.toml)",
+	FlagRootProfile:        "configuration profile to use (env: SCION_PROFILE)",
+	FlagRootVersion:        "show version information",
+	FlagRootNoColor:        "disable colored output (env: NO_COLOR)",
+	FlagRootVerbose:        "show detailed output",
+	FlagRootQuiet:          "show only warnings and errors",
+	FlagRootDebug:          "show debug output including executed git commands and their timings",
+	FlagRootYes:            "answer yes to all confirmation prompts (env: SCION_ASSUME_YES)",
+	FlagRootErrorFormat:    "error output format (text or json)",
+	FlagCreateBase:         "base branch (default: value from the configuration file or the current branch)",
+	FlagCreateRemote:       "remote repository (default: origin)",
+	FlagCreateForce:        "overwrite an existing worktree",
+	FlagCreateTrack:        "create a local branch tracking a remote branch (e.g. origin/feature-x)",
+	FlagCreatePR:           "check out a pull request by number (fetched from git.pull_request_ref)",
+	FlagCreateDetach:       "create a worktree at a commit or tag without creating a branch (detached HEAD)",
+	FlagCreatePush:         "push the new branch to the remote and set it as upstream (default: git.push_on_create)",
+	FlagCreateSparse:       "check out only these directories with a cone-mode sparse checkout (default: worktree.sparse_paths)",
+	FlagCreateNoSparse:     "check out all files even if worktree.sparse_paths is set",
+	FlagClearForce:         "remove even if there are uncommitted changes",
+	FlagClearAll:           "remove all worktrees",
+	FlagClearKeepBranch:    "remove the worktree but keep the branch",
+	FlagConfigGlobal:       "target the global configuration",
+	FlagConfigLocal:        "target the local configuration",
+	FlagConfigMigrateDry:   "show migrations that would be applied without changing files",
+	FlagConfigSchemaOutput: "output file path (default: standard output)",
+	FlagListFormat:         "print worktrees using a Go template (e.g. '{{.Branch}}\\t{{.Path}}')",
+	FlagLogBranch:          "show only operations on this branch",
+	FlagLogAction:          "show only this kind of operation (create, clear, config_set, config_reset, sync, land)",
+	FlagLogLimit:           "maximum number of entries to show (0 for all)",
+	FlagLogAllRepos:        "show operations on all repositories",
+	FlagLogFormat:          "print entries using a Go template (e.g. '{{.Time}}\\t{{.Action}}')",
+	FlagSyncAll:            "sync all worktrees except the main worktree",
+	FlagSyncMode:           "how to bring in the base: rebase or merge (default: git.sync_mode)",
+	FlagSyncNoFetch:        "do not fetch before syncing",
+	FlagLandSquash:         "squash the branch into a single commit on the base",
+	FlagLandRebase:         "rebase the branch onto the base and fast-forward the base",
+	FlagLandFFOnly:         "land only if the base can be fast-forwarded",
+	FlagLandClear:          "remove the worktree and its branch after landing",
+	FlagLandNoVerify:       "do not run the hooks.pre_land commands",
+
+	// 共通メッセージ
+	MsgNotGitRepository:          "Run this command inside a Git repository",
+	MsgCancelled:                 "Cancelled",
+	MsgConfigLoadFailed:          "Failed to load configuration file: %w",
+	MsgForceHint:                 "%w\nUse --force to remove anyway",
+	MsgConfirmNotInteractive:     "confirmation required but standard input is not a terminal\nUse --yes or SCION_ASSUME_YES=1 to proceed without confirmation",
+	MsgInvalidErrorFormat:        "invalid error format: %s (expected text or json)",
+	MsgConfirmTimeout:            "no answer to the confirmation prompt within %d seconds",
+	MsgInvalidFormat:             "invalid --format template: %w",
+	MsgBranchRequired:            "Specify a branch name (a picker is shown when run in a terminal)",
+	MsgWorktreeNotFoundForBranch: "No worktree found for branch '%s'",
+	MsgEditorNotSet:              "No editor configured. Set $EDITOR or editor.command",
+	MsgOpenOpening:               "Opening %s",
+
+	// create コマンド
+	MsgCreateApplyingRule:        "Applying rule '%s'",
+	MsgCreateFetching:            "Fetching latest changes from remote...",
+	MsgCreateFetchFailed:         "Fetch failed: %v",
+	MsgCreateWorktreeExists:      "Worktree '%s' already exists\nUse --force to overwrite existing worktree",
+	MsgCreateBranchExists:        "Branch '%s' already exists. Checking out the existing branch",
+	MsgCreateRemovingExisting:    "Removing existing worktree...",
+	MsgCreateRemoveFailed:        "Failed to remove existing worktree: %w",
+	MsgCreateCreating:            "Creating worktree for branch: %s",
+	MsgCreateCreatedDir:          "Worktree directory created: %s",
+	MsgCreateSparse:              "Sparse checkout: %s",
+	MsgCreateUpdatingSubmodules:  "Updating submodules",
+	MsgCreateSubmodulesUpdated:   "Submodules updated",
+	MsgCreateSubmodulesFailed:    "Failed to update submodules: %v",
+	MsgCreatePullingLFS:          "Pulling Git LFS files",
+	MsgCreateLFSPulled:           "Git LFS files pulled",
+	MsgCreateLFSFailed:           "Failed to pull Git LFS files: %v",
+	MsgCreateLFSNotInstalled:     "This repository uses Git LFS but git-lfs is not installed; files are left as pointers",
+	MsgCreateCreatedBranch:       "Branch '%s' created and checked out",
+	MsgCreateCheckedOutBranch:    "Branch '%s' checked out",
+	MsgCreatePath:                "Path: %s",
+	MsgCreateBranchRequired:      "branch name is required unless --track or --pr is given",
+	MsgCreateNotRemoteBranch:     "'%s' is not a remote branch (expected <remote>/<branch>)",
+	MsgCreateRemoteNotFound:      "remote branch '%s' not found",
+	MsgCreateTracking:            "Tracking remote branch: %s",
+	MsgCreateFetchingPR:          "Fetching pull request #%d from %s",
+	MsgCreatePRRefInvalid:        "git.pull_request_ref must contain '*' for the pull request number: %s",
+	MsgCreatePRUpdated:           "Fast-forwarded '%s' to pull request #%d",
+	MsgCreatePRDiverged:          "branch '%s' has commits that are not in pull request #%d; delete the branch or choose another branch name",
+	MsgCreateCreatingDetached:    "Creating detached worktree at: %s",
+	MsgCreateDetachedAt:          "HEAD detached at %s (%s)",
+	MsgCreatePushing:             "Pushing %s to %s",
+	MsgCreatePushed:              "Pushed branch '%s' to %s",
+	MsgCreatePushFailed:          "Failed to push the branch: %v",
+	MsgCreateUpstreamSet:         "Upstream set to %s",
+	MsgCreateUpstreamFailed:      "Failed to set the upstream: %v",
+	MsgCreateUpstreamNoBase:      "Base '%s' is not a branch; upstream not set",
+	MsgCreateUpstreamModeInvalid: "unknown git.upstream_mode '%s' (available: none, push, base)",
+	MsgHookRunning:               "Running %s hook: %s",
+	MsgHookFailed:                "%s hook '%s' failed: %w",
+
+	// clear コマンド
+	MsgClearBranchRequired:     "Specify a branch name (or use the --all flag; a picker is shown when run in a terminal)",
+	MsgClearNothingToRemove:    "No worktrees to remove",
+	MsgClearTargets:            "Worktrees to remove:",
+	MsgClearConfirmAll:         "Are you sure you want to remove all worktrees?",
+	MsgClearConfirm:            "Are you sure you want to remove the worktree for %s?",
+	MsgClearConfirmSelected:    "Are you sure you want to remove %d worktree(s)?",
+	MsgClearRemovedAll:         "All worktrees cleared successfully",
+	MsgClearWorktreeNotFound:   "Worktree '%s' not found",
+	MsgClearStatusCheckFailed:  "failed to check for uncommitted changes: %w",
+	MsgClearUncommittedChanges: "Worktree '%s' has uncommitted changes\nUse --force to remove anyway, or commit/stash your changes first",
+	MsgClearRemoving:           "Removing worktree: %s",
+	MsgClearRemovedWorktree:    "Worktree removed: %s",
+	MsgClearBranchDeleteFailed: "Failed to delete branch: %v",
+	MsgClearDeletedBranch:      "Branch '%s' deleted",
+	MsgClearSomeFailed:         "Some worktrees could not be removed",
+
+	// list コマンド
+	MsgListHeaderBranch: "BRANCH",
+	MsgListHeaderHead:   "HEAD",
+	MsgListHeaderPath:   "PATH",
+	MsgListBare:         "(bare)",
+	MsgListDetached:     "(detached)",
+
+	// log コマンド
+	MsgLogHeaderTime:          "TIME",
+	MsgLogHeaderAction:        "ACTION",
+	MsgLogHeaderOutcome:       "RESULT",
+	MsgLogEmpty:               "No operations recorded",
+	MsgLogReadFailed:          "Failed to read the audit log: %w",
+	MsgSyncFetching:           "Fetching %s",
+	MsgSyncFetchFailed:        "Failed to fetch %s: %v",
+	MsgSyncRunning:            "Syncing %s onto %s",
+	MsgSyncNothing:            "No worktrees to sync",
+	MsgSyncHeaderBase:         "BASE",
+	MsgSyncHeaderResult:       "RESULT",
+	MsgSyncUpdated:            "updated",
+	MsgSyncUpToDate:           "up to date",
+	MsgSyncSkippedDirty:       "skipped: uncommitted changes",
+	MsgSyncSkippedDetached:    "skipped: detached HEAD",
+	MsgSyncSkippedSelf:        "skipped: branch is its own base",
+	MsgSyncConflict:           "conflict (aborted)",
+	MsgSyncFailed:             "failed: %s",
+	MsgSyncConflicts:          "%d worktree(s) could not be synced because of conflicts; run git %s manually",
+	MsgSyncSomeFailed:         "failed to sync some worktrees",
+	MsgSyncModeInvalid:        "unknown sync mode '%s' (available: rebase, merge)",
+	MsgLandMainWorktree:       "cannot land the main worktree",
+	MsgLandUncommittedChanges: "Worktree '%s' has uncommitted changes; commit or stash them before landing",
+	MsgLandSameBase:           "'%s' is its own base branch",
+	MsgLandBaseNotBranch:      "base '%s' is not a local branch",
+	MsgLandBaseDirty:          "could not update base branch '%s' because its worktree %s has uncommitted changes to the landed files; commit or stash them and try again: %w",
+	MsgLandTemporary:          "Using a temporary worktree for %s: %s",
+	MsgLandTemporaryFailed:    "Failed to remove the temporary worktree: %v",
+	MsgLandRebasing:           "Rebasing %s onto %s",
+	MsgLandLanding:            "Landing %s into %s (%s)",
+	MsgLandAlreadyMerged:      "'%s' is already merged into '%s'",
+	MsgLandLanded:             "Landed '%s' into '%s'",
+	MsgLandConflict:           "could not land '%s' into '%s' because of conflicts; both branches were left unchanged",
+	MsgAuditWriteFailed:       "Failed to write the audit log: %v",
+
+	// ピッカー
+	MsgPickerHint:       "Enter: select  Esc: cancel",
+	MsgPickerHintMulti:  "Tab: mark  Enter: confirm  Esc: cancel",
+	MsgPickerPrompt:     "worktree",
+	MsgPickerNoWorktree: "No worktrees to choose from",
+
+	// ui コマンド
+	MsgUITitle:             "scion — %s",
+	MsgUIHeaderChanges:     "CHANGES",
+	MsgUIHeaderSync:        "SYNC",
+	MsgUIHeaderAgents:      "AGENTS",
+	MsgUILockedMark:        "[locked]",
+	MsgUIAgentsUnsupported: "unsupported",
+	MsgUIHint:              "j/k: move  c: create  d: remove  a: agent  o: editor  v: diff  l: lock  r: refresh  q: quit",
+	MsgUIDiffHint:          "j/k: scroll  space/b: page  q: back",
+	MsgUICreatePrompt:      "New branch: ",
+	MsgUIClearConfirm:      "Remove %s? [y/N]",
+	MsgUIPressAnyKey:       "Press any key to return",
+	MsgUIMainWorktree:      "This cannot be done for the main worktree",
+	MsgUILocked:            "Locked %s",
+	MsgUIUnlocked:          "Unlocked %s",
+	MsgUINoDiff:            "No changes",
+	MsgUINotTerminal:       "The ui command can only be used in a terminal",
+	MsgUIAgentNotSet:       "No agent command is set (agent.command)",
+
+	// config コマンド
+	MsgConfigUpdated:          "Configuration updated: %s = %s",
+	MsgConfigActiveProfile:    "Active profile: %s",
+	MsgConfigGlobalHeader:     "Global Configuration (%s):",
+	MsgConfigLocalHeader:      "Local Configuration (%s):",
+	MsgConfigLocalExists:      "(local configuration exists)",
+	MsgConfigProfileActive:    "(active)",
+	MsgConfigConfirmReset:     "Are you sure you want to reset all configurations to default?",
+	MsgConfigResetDone:        "Configuration reset to defaults",
+	MsgConfigMigrateFailed:    "Failed to migrate %s: %w",
+	MsgConfigUpToDate:         "%s is up to date (v%d)",
+	MsgConfigWillMigrate:      "%s: would be migrated v%d → v%d",
+	MsgConfigMigrated:         "Migrated %s: v%d → v%d (backup: %s.bak)",
+	MsgConfigNoFiles:          "No configuration files found",
+	MsgConfigSchemaFailed:     "Failed to generate JSON Schema: %w",
+	MsgConfigSchemaWritten:    "JSON Schema written: %s",
+	MsgConfigNewerVersionLoad: "%s: %v (unrecognized settings are ignored)",
+	MsgConfigOutdated:         "%s uses an outdated configuration format (v%d → v%d). Run 'scion config migrate' to update it",
+
+	// 設定パッケージのエラー
+	MsgMigrationBaseBranch:      "merge repository.base_branch into git.default_base_branch",
+	MsgInvalidVersion:           "invalid version: %v",
+	MsgNewerVersion:             "configuration file version %d is newer than the version supported by this scion (%d)",
+	MsgMigrationFailed:          "migration (v%d → v%d) failed: %w",
+	MsgMigrationMissing:         "no migration defined from version %d",
+	MsgBackupFailed:             "failed to create backup: %w",
+	MsgKeyEmpty:                 "key is empty",
+	MsgKeyInvalid:               "invalid key format: %s",
+	MsgKeyMissingBracket:        "invalid key format: %s (missing ']')",
+	MsgKeyEmptyIndex:            "invalid key format: %s (empty index)",
+	MsgKeyNotFound:              "key '%s' not found",
+	MsgKeyNotSet:                "key '%s' has no value",
+	MsgKeyNotList:               "'%s' is not a list",
+	MsgKeyListElement:           "'%s' is not a valid list element (use [n] or [+])",
+	MsgKeyAppendOnlySet:         "[+] can only be used when setting a value",
+	MsgKeyInvalidIndex:          "invalid index: %s",
+	MsgKeyIndexOutOfRange:       "index %s is out of range (length: %d)",
+	MsgKeyUnsupportedMap:        "unsupported map type: %v",
+	MsgKeyNoNested:              "'%s' has no nested keys",
+	MsgValueInvalidDuration:     "invalid duration: %s (e.g. 30s, 5m)",
+	MsgValueInvalidBool:         "invalid bool value: %s",
+	MsgValueInvalidNumber:       "invalid number: %s",
+	MsgValueInvalidTOML:         "invalid value: %s (use TOML syntax: %v)",
+	MsgValueUnsupportedType:     "unsupported type: %v",
+	MsgProfileNotFound:          "profile '%s' not found",
+	MsgProfileApplyFailed:       "failed to apply profile '%s': %w",
+	MsgProfileReservedKey:       "'%s' cannot be set inside a profile",
+	MsgProfileLoadFailed:        "failed to load profile '%s': %w",
+	MsgProfileSectionNotAllowed: "a whole section cannot be set in a profile; specify individual keys",
+	MsgRulePatternMissing:       "rule has no pattern",
+	MsgRulePatternInvalid:       "invalid rule pattern '%s': %w",
+
+	// 設定キーの説明
+	MsgDescConfig:                        "scion configuration file (config.toml)",
+	MsgDescVersion:                       "Configuration file version (updated by scion config migrate)",
+	MsgDescRepository:                    "Repository settings",
+	MsgDescWorktree:                      "Worktree settings",
+	MsgDescGit:                           "Git settings",
+	MsgDescUI:                            "UI settings",
+	MsgDescEditor:                        "Editor settings",
+	MsgDescAgent:                         "AI agent settings",
+	MsgDescHooks:                         "Hook settings",
+	MsgDescRules:                         "Settings per branch name pattern (applied in the order they are defined)",
+	MsgDescProfiles:                      "Named profiles (selected with --profile or SCION_PROFILE)",
+	MsgDescRepositoryBaseRepository:      "Path of the repository the commands operate on",
+	MsgDescWorktreePlacement:             "How worktrees are placed (sibling: next to the repository, per_repo: base_dir/repository name, inside: inside the repository, root: root/host/owner/repository name)",
+	MsgDescWorktreeBaseDir:               "Base name of the worktree directory",
+	MsgDescWorktreeRoot:                  "Directory to place worktrees in when placement = root",
+	MsgDescWorktreeDirTemplate:           "Template for worktree paths (takes precedence over placement, relative paths are based on the repository's parent directory, functions: slug, escape, lower)",
+	MsgDescWorktreeAutoCreateDir:         "Create the wtree directory automatically",
+	MsgDescWorktreeCleanupOnBranchDelete: "Remove the worktree when its branch is deleted",
+	MsgDescWorktreeSparsePaths:           "When set, check out only these directories with a cone-mode sparse checkout (for monorepos)",
+	MsgDescGitDefaultRemote:              "Default remote name",
+	MsgDescGitDefaultBaseBranch:          "Default base branch",
+	MsgDescGitFetchBeforeCreate:          "Run fetch before creating a worktree",
+	MsgDescGitPushOnCreate:               "Push newly created branches to the remote and set them as upstream",
+	MsgDescGitSubmodules:                 "Initialize and update submodules after creating a worktree (when .gitmodules exists)",
+	MsgDescGitSubmoduleReference:         "Pass submodules initialized in the main worktree to --reference to avoid downloading them again",
+	MsgDescGitLFSPull:                    "Run git lfs pull after creating a worktree (when .gitattributes uses LFS)",
+	MsgDescGitUpstreamMode:               "Upstream of new branches (none: not set, push: the branch of the same name on the remote, base: the base branch)",
+	MsgDescGitSyncMode:                   "How scion sync brings in the base (rebase: rebase, merge: merge)",
+	MsgDescGitPullRequestRef:             "Remote ref fetched by scion create --pr (* is the pull request number, GitLab: refs/merge-requests/*/head)",
+	MsgDescUIColorOutput:                 "Enable colored output",
+	MsgDescUIVerbose:                     "Verbose output",
+	MsgDescUIConfirmDestructive:          "Confirm destructive operations",
+	MsgDescUILanguage:                    "Display language (auto: detect from the LC_ALL/LC_MESSAGES/LANG environment variables)",
+	MsgDescUIPromptTimeout:               "Seconds to wait for an answer to a confirmation prompt (0: no limit)",
+	MsgDescEditorCommand:                 "Default editor",
+	MsgDescAgentCommand:                  "Agent command launched from scion ui",
+	MsgDescAgentProcesses:                "Process names detected as running agents",
+	MsgDescHooksPostCreate:               "Commands run inside the worktree after it is created",
+	MsgDescHooksPreClear:                 "Commands run inside the worktree before it is removed",
+	MsgDescHooksPreLand:                  "Commands run inside the worktree before scion land brings it in (such as tests)",
+	MsgDescRulePattern:                   "Glob pattern of the target branches (e.g. hotfix/*)",
+	MsgDescRuleBaseBranch:                "Base branch to create the worktree's branch from",
+	MsgDescRuleRemote:                    "Remote name to use",
+	MsgDescRulePlacement:                 "How worktrees are placed",
+	MsgDescRuleBaseDir:                   "Base name of the worktree directory",
+	MsgDescRuleDirTemplate:               "Template for worktree paths",
+	MsgDescRuleFetchBeforeCreate:         "Run fetch before creating a worktree",
+	MsgDescRulePushOnCreate:              "Push newly created branches to the remote",
+	MsgDescRuleSparsePaths:               "Directories to check out with sparse checkout (an empty list disables it)",
+	MsgDescRuleHooks:                     "Hooks used by this rule (replace the existing hooks when set)",
+
+	// worktree のディレクトリ
+	MsgLayoutInvalidTemplate:  "invalid worktree.dir_template: %w",
+	MsgLayoutEmptyPath:        "worktree.dir_template produced an empty path for branch '%s'",
+	MsgLayoutRepoPath:         "worktree.dir_template for branch '%s' points at the repository itself: %s",
+	MsgLayoutCollision:        "%s is already used by branch '%s'; using %s instead",
+	MsgLayoutStoreFailed:      "Failed to update the worktree mapping: %v",
+	MsgLayoutUnknownPlacement: "unknown worktree.placement '%s' (available: sibling, per_repo, inside, root)",
+	MsgLayoutExcludeFailed:    "Failed to add the worktree directory to .git/info/exclude: %v",
+
+	// git パッケージのエラー
+	MsgGitRootFailed:           "failed to get Git repository root: %s",
+	MsgGitCurrentBranchFailed:  "failed to get current branch: %s",
+	MsgGitHeadFailed:           "failed to resolve HEAD: %s",
+	MsgGitResolveFailed:        "failed to resolve revision: %s",
+	MsgGitUpdateRefFailed:      "failed to update branch: %s",
+	MsgGitPathExists:           "%s already exists and is not empty",
+	MsgGitBranchCheckedOut:     "branch '%s' is already checked out at %s",
+	MsgGitBaseBranchNotFound:   "base branch '%s' not found",
+	MsgGitRevisionNotFound:     "revision '%s' not found",
+	MsgGitBranchNotFound:       "branch '%s' not found",
+	MsgGitMkdirFailed:          "failed to create worktree directory: %w",
+	MsgGitWorktreeAddFailed:    "failed to create worktree: %s",
+	MsgGitSparseCheckoutFailed: "sparse checkout failed: %s",
+	MsgGitSubmoduleFailed:      "submodule update failed: %s",
+	MsgGitLFSPullFailed:        "git lfs pull failed: %s",
+	MsgGitWorktreeRemoveFailed: "failed to remove worktree: %s",
+	MsgGitBranchDeleteFailed:   "failed to delete branch: %s",
+	MsgGitWorktreeListFailed:   "failed to list worktrees: %s",
+	MsgGitBranchListFailed:     "failed to list branches: %s",
+	MsgGitTagListFailed:        "failed to list tags: %s",
+	MsgGitRemoteListFailed:     "failed to list remotes: %s",
+	MsgGitRemoteURLFailed:      "failed to get the remote URL: %s",
+	MsgGitStatusFailed:         "failed to check status: %s",
+	MsgGitFetchFailed:          "fetch failed: %s",
+	MsgGitPushFailed:           "push failed: %s",
+	MsgGitSetUpstreamFailed:    "failed to set upstream: %s",
+	MsgGitRebaseFailed:         "rebase failed: %s",
+	MsgGitMergeFailed:          "merge failed: %s",
+	MsgGitFastForwardFailed:    "cannot fast-forward: %s",
+	MsgGitCommitFailed:         "commit failed: %s",
+	MsgGitDiffFailed:           "failed to get diff: %s",
+	MsgGitLockFailed:           "failed to lock worktree: %s",
+	MsgGitUnlockFailed:         "failed to unlock worktree: %s",
+
+	// 詳細出力とデバッグ出力
+	MsgDebugCommand:       "%s (%s)",
+	MsgDebugCommandFailed: "%s (%s, %v)",
+	MsgVerboseLoadedFile:  "Loaded configuration file: %s",
+	MsgVerboseProfile:     "Using profile: %s",
+	MsgVerboseLocale:      "Display language: %s",
+	MsgVerboseCreatePlan:  "Base branch: %s, remote: %s, worktree directory: %s",
+}
@@ -0,0 +1,518 @@
+package i18n
+
+// catalogJa は日本語のメッセージカタログ
+var catalogJa = map[MessageID]string{
+	// コマンドのヘルプテキスト
+	CmdRootShort: "Git Worktreeを効率的に管理するCLIツール",
+	CmdRootLong: `scionはGit Worktreeを効率的に管理するためのCLIツールです。
+AI開発環境（Claude Code、GitHub Copilot、Cursor）との連携を前提とした、
+シンプルで使いやすいワークフロー管理を提供します。
+
+利用可能なコマンド:
+  create  - 新しいworktreeブランチを作成
+  clear   - 既存のworktreeブランチを削除
+  list    - worktreeの一覧を表示
+  cd      - worktreeのパスを表示
+  open    - worktreeをエディタで開く
+  ui      - worktreeのダッシュボードを表示
+  config  - scionの設定を管理`,
+	CmdCreateShort: "新しいworktreeブランチを作成",
+	CmdCreateLong: `create コマンドは新しいGit Worktreeブランチを作成し、専用のディレクトリに配置します。
+
+worktreeディレクトリはベースリポジトリと同一階層に作成されます。
+
+例:
+  scion create feature/new-feature
+  scion create feature/payment --base develop
+  scion create bugfix/issue-123 --remote upstream
+  scion create feature/refactor --force
+  scion create --track origin/feature-x
+  scion create --pr 123`,
+	CmdClearShort: "既存のworktreeブランチを削除",
+	CmdClearLong: `clear コマンドは既存のGit Worktreeブランチとその関連ディレクトリを削除します。
+
+例:
+  scion clear feature/old-feature
+  scion clear feature/experimental --force
+  scion clear feature/temp --keep-branch
+  scion clear --all`,
+	CmdConfigShort: "scionの設定を管理",
+	CmdConfigLong: `config コマンドはscionの設定を管理します。
+
+サブコマンド:
+  get <key>      - 特定の設定値を取得
+  set <key> <value> - 設定値を更新
+  list           - すべての設定を表示
+  reset          - 設定をデフォルトに戻す
+  edit           - エディタで設定ファイルを開く
+  schema         - 設定ファイルのJSON Schemaを出力
+  migrate        - 設定ファイルを現在の形式に更新
+
+例:
+  scion config get worktree.base_dir
+  scion config set ui.color_output false
+  scion config set hooks.post_create[+] "npm ci"
+  scion config list
+  scion config edit
+  scion config schema --output .scion/config.schema.json`,
+	CmdConfigGetShort:    "特定の設定値を取得",
+	CmdConfigSetShort:    "設定値を更新",
+	CmdConfigListShort:   "すべての設定を表示",
+	CmdConfigResetShort:  "設定をデフォルトに戻す",
+	CmdConfigEditShort:   "エディタで設定ファイルを開く",
+	CmdConfigSchemaShort: "設定ファイルのJSON Schemaを出力",
+	CmdConfigSchemaLong: `schema コマンドはConfig構造体から生成したJSON Schemaを出力します。
+
+taplo などのTOML言語サーバーに読み込ませることで、config.toml の補完と検証ができます。
+
+例:
+  scion config schema
+  scion config schema --output ~/.config/scion/config.schema.json`,
+	CmdConfigMigrateShort: "設定ファイルを現在の形式に更新",
+	CmdConfigMigrateLong: `migrate コマンドは古いバージョンのscionで作成された設定ファイルを現在の形式に更新します。
+
+更新前のファイルは <ファイル名>.bak として保存されます。
+--global / --local を指定しない場合は、存在するすべての設定ファイルを対象とします。
+
+例:
+  scion config migrate
+  scion config migrate --local
+  scion config migrate --dry-run`,
+	CmdListShort: "worktreeの一覧を表示",
+	CmdListLong: `list コマンドはリポジトリのworktreeの一覧を表示します。
+
+--format を指定すると、表の代わりにGoテンプレートで各worktreeを出力します。
+使用できるフィールド: .Branch, .Path, .Head, .Main, .Bare, .Detached
+使用できる関数: json, upper, lower, join
+
+例:
+  scion list
+  scion list --format '{{.Branch}}\t{{.Path}}'
+  scion list --format '{{json .}}'`,
+	CmdCdShort: "worktreeのパスを表示",
+	CmdCdLong: `cd コマンドはブランチのworktreeのパスを表示します。
+
+プログラムから親シェルのディレクトリは変更できないため、cd と組み合わせて使用します。
+ブランチ名を省略すると、端末ではピッカーで選択できます。
+
+例:
+  cd "$(scion cd feature/login)"
+  cd "$(scion cd)"
+
+シェル関数:
+  scd() { cd "$(scion cd "$@")"; }`,
+	CmdOpenShort: "worktreeをエディタで開く",
+	CmdOpenLong: `open コマンドはブランチのworktreeをエディタで開きます。
+
+エディタは $EDITOR または設定の editor.command を使用します。
+ブランチ名を省略すると、端末ではピッカーで選択できます。
+
+例:
+  scion open feature/login
+  scion open`,
+	CmdUIShort: "worktreeのダッシュボードを表示",
+	CmdUILong: `ui コマンドはworktreeの状態を全画面のダッシュボードに表示します。
+
+未コミットの変更があるファイル数、上流ブランチとの差分のコミット数、
+実行中のAIエージェントを2秒ごとに更新して表示します。
+ダッシュボードからworktreeの作成、削除、ロックと、
+エージェントやエディタでの起動ができます。
+
+キー操作:
+  j/k, ↑/↓  カーソルを移動
+  c         worktreeを作成
+  d         worktreeを削除
+  a         worktreeでエージェント (agent.command) を起動
+  o         worktreeをエディタで開く
+  v         差分を表示
+  l         worktreeをロック/ロック解除
+  r         表示を更新
+  q         終了`,
+	CmdLogShort: "操作の履歴を表示",
+	CmdLogLong: `log コマンドはworktreeや設定を変更した操作（create、clear、config set、
+config reset）の監査ログを表示します。
+
+各操作には日時、ユーザー、リポジトリ、ブランチ、パス、操作前後にチェックアウト
+していたコミットと成否が記録されます。
+ログは $XDG_STATE_HOME/scion/audit.jsonl
+（デフォルト: ~/.local/state/scion/audit.jsonl）に保存されます。
+
+リポジトリ内で実行した場合は、そのリポジトリの操作だけを表示します。
+
+--format を指定すると、表の代わりにGoテンプレートで各操作を出力します。
+利用できるフィールド: .Time, .Action, .User, .Repo, .Branch, .Path, .Before,
+.After, .Outcome, .Error, .Details
+
+例:
+  scion log
+  scion log --branch feature/login
+  scion log --action clear --limit 5
+  scion log --format '{{json .}}'`,
+	CmdSyncShort: "worktreeのブランチをベースブランチにリベースまたはマージ",
+	CmdSyncLong: `sync コマンドは一度だけfetchしてから、各worktreeのブランチを作成元のベースに
+リベース（またはマージ）します。ベースが記録されていないworktreeは
+git.default_base_branch を使用します。ベースがリモートにある場合は、fetchした
+リモート追跡ブランチを使用します。
+
+未コミットの変更があるworktreeとdetached HEADのworktreeはスキップします。
+衝突した場合はリベースやマージを中止し、worktreeを元の状態に戻します。
+
+例:
+  scion sync feature/login
+  scion sync --all
+  scion sync --all --mode merge`,
+	CmdLandShort: "worktreeのブランチをベースブランチに取り込み、必要に応じて削除",
+	CmdLandLong: `land コマンドは、worktreeのブランチを作成元のベースブランチに取り込みます。
+worktreeに未コミットの変更がないことが必要です。
+
+ベースブランチをチェックアウトしているworktree（通常はメインworktree）で
+取り込みます。ベースブランチがどこにもチェックアウトされていない場合や、
+そのworktreeに未コミットの変更がある場合は、一時的なworktreeで取り込んでから
+ベースブランチを取り込んだコミットまで進めます。
+
+取り込む前に hooks.pre_land のコマンド（テストなど）をworktree内で、取り込む
+コミット（--rebase の場合はリベース後）に対して実行し、失敗した場合は中止します。--clear を指定すると、取り込んだ後にworktreeと
+ブランチを削除します。
+
+例:
+  scion land feature/login
+  scion land feature/login --squash --clear
+  scion land feature/login --rebase --no-verify`,
+
+	// フラグの説明
+	FlagRootConfig:         "設定ファイルのパス (デフォルト: ~/.config/scion/config.toml)",
+	FlagRootProfile:        "使用する設定プロファイル (環境変数: SCION_PROFILE)",
+	FlagRootVersion:        "バージョン情報を表示",
+	FlagRootNoColor:        "カラー出力を無効化 (環境変数: NO_COLOR)",
+	FlagRootVerbose:        "詳細な出力を表示",
+	FlagRootQuiet:          "警告とエラーのみを表示",
+	FlagRootDebug:          "実行したgitコマンドと所要時間を含むデバッグ情報を表示",
+	FlagRootYes:            "すべての確認に自動で同意 (環境変数: SCION_ASSUME_YES)",
+	FlagRootErrorFormat:    "エラーの出力形式 (text または json)",
+	FlagCreateBase:         "ベースブランチを指定 (デフォルト: 設定ファイルの値または現在のブランチ)",
+	FlagCreateRemote:       "リモートリポジトリを指定 (デフォルト: origin)",
+	FlagCreateForce:        "既存のworktreeを強制的に上書き",
+	FlagCreateTrack:        "リモートブランチを追跡するローカルブランチを作成 (例: origin/feature-x)",
+	FlagCreatePR:           "番号を指定してプルリクエストをチェックアウト (git.pull_request_ref から取得)",
+	FlagCreateDetach:       "ブランチを作成せずに、コミットやタグを detached HEAD でチェックアウトした worktree を作成",
+	FlagCreatePush:         "新しいブランチをリモートにpushし、上流に設定 (デフォルト: git.push_on_create)",
+	FlagCreateSparse:       "cone モードの sparse checkout でこのディレクトリだけをチェックアウト (デフォルト: worktree.sparse_paths)",
+	FlagCreateNoSparse:     "worktree.sparse_paths が設定されていてもすべてのファイルをチェックアウト",
+	FlagClearForce:         "未コミットの変更があっても強制的に削除",
+	FlagClearAll:           "すべてのworktreeを削除",
+	FlagClearKeepBranch:    "worktreeは削除するがブランチは保持",
+	FlagConfigGlobal:       "グローバル設定を対象とする",
+	FlagConfigLocal:        "ローカル設定を対象とする",
+	FlagConfigMigrateDry:   "ファイルを変更せずに適用されるマイグレーションを表示",
+	FlagConfigSchemaOutput: "出力先のファイルパス (デフォルト: 標準出力)",
+	FlagListFormat:         "Goテンプレートでworktreeを出力 (例: '{{.Branch}}\\t{{.Path}}')",
+	FlagLogBranch:          "指定したブランチの操作だけを表示",
+	FlagLogAction:          "指定した種類の操作だけを表示 (create, clear, config_set, config_reset, sync, land)",
+	FlagLogLimit:           "表示する最大件数 (0 ですべて)",
+	FlagLogAllRepos:        "すべてのリポジトリの操作を表示",
+	FlagLogFormat:          "Goテンプレートで操作を出力 (例: '{{.Time}}\\t{{.Action}}')",
+	FlagSyncAll:            "メインworktree以外のすべてのworktreeを更新",
+	FlagSyncMode:           "ベースの取り込み方: rebase または merge (デフォルト: git.sync_mode)",
+	FlagSyncNoFetch:        "更新前にfetchしない",
+	FlagLandSquash:         "ブランチの変更を1つのコミットにまとめて取り込む",
+	FlagLandRebase:         "ブランチをベースにリベースしてから早送りで取り込む",
+	FlagLandFFOnly:         "早送りできる場合のみ取り込む",
+	FlagLandClear:          "取り込んだ後にworktreeとブランチを削除",
+	FlagLandNoVerify:       "hooks.pre_land のコマンドを実行しない",
+
+	// 共通メッセージ
+	MsgNotGitRepository:          "Gitリポジトリ内で実行してください",
+	MsgCancelled:                 "キャンセルしました",
+	MsgConfigLoadFailed:          "設定ファイルの読み込みに失敗しました: %w",
+	MsgForceHint:                 "%w\n--force オプションで強制削除できます",
+	MsgConfirmNotInteractive:     "確認が必要ですが、標準入力が端末ではありません\n--yes または SCION_ASSUME_YES=1 で確認せずに実行できます",
+	MsgInvalidErrorFormat:        "不正なエラーの出力形式です: %s (text または json を指定してください)",
+	MsgConfirmTimeout:            "%d 秒以内に確認への応答がありませんでした",
+	MsgInvalidFormat:             "--format のテンプレートが無効です: %w",
+	MsgBranchRequired:            "ブランチ名を指定してください (端末ではピッカーで選択できます)",
+	MsgWorktreeNotFoundForBranch: "ブランチ '%s' のworktreeが見つかりません",
+	MsgEditorNotSet:              "エディタが設定されていません。$EDITOR または editor.command を設定してください",
+	MsgOpenOpening:               "%s を開いています",
+
+	// create コマンド
+	MsgCreateApplyingRule:        "ルール '%s' を適用します",
+	MsgCreateFetching:            "リモートから最新の情報を取得しています...",
+	MsgCreateFetchFailed:         "fetchに失敗しました: %v",
+	MsgCreateWorktreeExists:      "worktree '%s' は既に存在します\n--force オプションで上書きできます",
+	MsgCreateBranchExists:        "ブランチ '%s' は既に存在します。既存のブランチをチェックアウトします",
+	MsgCreateRemovingExisting:    "既存のworktreeを削除しています...",
+	MsgCreateRemoveFailed:        "既存のworktreeの削除に失敗しました: %w",
+	MsgCreateCreating:            "worktreeを作成しています: %s",
+	MsgCreateCreatedDir:          "Worktree ディレクトリを作成しました: %s",
+	MsgCreateSparse:              "sparse checkout: %s",
+	MsgCreateUpdatingSubmodules:  "サブモジュールを更新中",
+	MsgCreateSubmodulesUpdated:   "サブモジュールを更新しました",
+	MsgCreateSubmodulesFailed:    "サブモジュールを更新できません: %v",
+	MsgCreatePullingLFS:          "Git LFS のファイルを取得中",
+	MsgCreateLFSPulled:           "Git LFS のファイルを取得しました",
+	MsgCreateLFSFailed:           "Git LFS のファイルを取得できません: %v",
+	MsgCreateLFSNotInstalled:     "このリポジトリは Git LFS を使用していますが、git-lfs がインストールされていません。ファイルはポインタのままです",
+	MsgCreateCreatedBranch:       "ブランチ '%s' を作成してチェックアウトしました",
+	MsgCreateCheckedOutBranch:    "ブランチ '%s' をチェックアウトしました",
+	MsgCreatePath:                "パス: %s",
+	MsgCreateBranchRequired:      "--track または --pr を指定しない場合はブランチ名が必要です",
+	MsgCreateNotRemoteBranch:     "'%s' はリモートブランチではありません (<リモート>/<ブランチ> の形式で指定してください)",
+	MsgCreateRemoteNotFound:      "リモートブランチ '%s' が見つかりません",
+	MsgCreateTracking:            "リモートブランチを追跡します: %s",
+	MsgCreateFetchingPR:          "プルリクエスト #%d を %s から取得中",
+	MsgCreatePRRefInvalid:        "git.pull_request_ref にはプルリクエストの番号を表す '*' が必要です: %s",
+	MsgCreatePRUpdated:           "'%s' をプルリクエスト #%d まで早送りしました",
+	MsgCreatePRDiverged:          "ブランチ '%s' にプルリクエスト #%d に含まれないコミットがあります。ブランチを削除するか、別のブランチ名を指定してください",
+	MsgCreateCreatingDetached:    "detached HEAD の worktree を作成中: %s",
+	MsgCreateDetachedAt:          "HEAD を %s (%s) で切り離しました",
+	MsgCreatePushing:             "%s を %s にpush中",
+	MsgCreatePushed:              "ブランチ '%s' を %s にpushしました",
+	MsgCreatePushFailed:          "ブランチをpushできません: %v",
+	MsgCreateUpstreamSet:         "上流を %s に設定しました",
+	MsgCreateUpstreamFailed:      "上流を設定できません: %v",
+	MsgCreateUpstreamNoBase:      "ベース '%s' はブランチではないため、上流を設定しません",
+	MsgCreateUpstreamModeInvalid: "git.upstream_mode '%s' は不明です (使用可能: none, push, base)",
+	MsgHookRunning:               "%s フックを実行しています: %s",
+	MsgHookFailed:                "%s フック '%s' が失敗しました: %w",
+
+	// clear コマンド
+	MsgClearBranchRequired:     "ブランチ名を指定してください (または --all フラグを使用。端末ではピッカーで選択できます)",
+	MsgClearNothingToRemove:    "削除するworktreeがありません",
+	MsgClearTargets:            "削除対象のworktree:",
+	MsgClearConfirmAll:         "すべてのworktreeを削除しますか?",
+	MsgClearConfirm:            "%s のworktreeを削除しますか?",
+	MsgClearConfirmSelected:    "%d 件のworktreeを削除しますか?",
+	MsgClearRemovedAll:         "すべてのworktreeを削除しました",
+	MsgClearWorktreeNotFound:   "worktree '%s' が見つかりません",
+	MsgClearStatusCheckFailed:  "未コミットの変更を確認できません: %w",
+	MsgClearUncommittedChanges: "worktree '%s' には未コミットの変更があります\n--force オプションで強制削除できます",
+	MsgClearRemoving:           "worktreeを削除しています: %s",
+	MsgClearRemovedWorktree:    "Worktreeを削除しました: %s",
+	MsgClearBranchDeleteFailed: "ブランチの削除に失敗しました: %v",
+	MsgClearDeletedBranch:      "ブランチ '%s' を削除しました",
+	MsgClearSomeFailed:         "一部のworktreeを削除できませんでした",
+
+	// list コマンド
+	MsgListHeaderBranch: "ブランチ",
+	MsgListHeaderHead:   "HEAD",
+	MsgListHeaderPath:   "パス",
+	MsgListBare:         "(bare)",
+	MsgListDetached:     "(detached)",
+
+	// log コマンド
+	MsgLogHeaderTime:          "日時",
+	MsgLogHeaderAction:        "操作",
+	MsgLogHeaderOutcome:       "結果",
+	MsgLogEmpty:               "記録された操作はありません",
+	MsgLogReadFailed:          "監査ログを読み込めません: %w",
+	MsgSyncFetching:           "%s からfetch中",
+	MsgSyncFetchFailed:        "%s からfetchできません: %v",
+	MsgSyncRunning:            "%s を %s に同期中",
+	MsgSyncNothing:            "同期するworktreeがありません",
+	MsgSyncHeaderBase:         "ベース",
+	MsgSyncHeaderResult:       "結果",
+	MsgSyncUpdated:            "更新",
+	MsgSyncUpToDate:           "最新",
+	MsgSyncSkippedDirty:       "スキップ: 未コミットの変更",
+	MsgSyncSkippedDetached:    "スキップ: detached HEAD",
+	MsgSyncSkippedSelf:        "スキップ: ブランチ自身がベース",
+	MsgSyncConflict:           "衝突 (中止しました)",
+	MsgSyncFailed:             "失敗: %s",
+	MsgSyncConflicts:          "%d 個のworktreeが衝突のため同期できませんでした。手動で git %s を実行してください",
+	MsgSyncSomeFailed:         "一部のworktreeを同期できませんでした",
+	MsgSyncModeInvalid:        "同期の方法 '%s' は不明です (使用可能: rebase, merge)",
+	MsgLandMainWorktree:       "メインworktreeは取り込めません",
+	MsgLandUncommittedChanges: "worktree '%s' に未コミットの変更があります。取り込む前にコミットまたはstashしてください",
+	MsgLandSameBase:           "'%s' はブランチ自身がベースブランチです",
+	MsgLandBaseNotBranch:      "ベース '%s' はローカルブランチではありません",
+	MsgLandBaseDirty:          "ベースブランチ '%s' をチェックアウトしている %s に取り込むファイルの未コミットの変更があるため、ベースブランチを更新できません。コミットまたはstashしてから再実行してください: %w",
+	MsgLandTemporary:          "%s の一時的なworktreeを使用します: %s",
+	MsgLandTemporaryFailed:    "一時的なworktreeを削除できません: %v",
+	MsgLandRebasing:           "%s を %s にリベース中",
+	MsgLandLanding:            "%s を %s に取り込み中 (%s)",
+	MsgLandAlreadyMerged:      "'%s' は '%s' に取り込み済みです",
+	MsgLandLanded:             "'%s' を '%s' に取り込みました",
+	MsgLandConflict:           "衝突のため '%s' を '%s' に取り込めませんでした。どちらのブランチも変更していません",
+	MsgAuditWriteFailed:       "監査ログに記録できません: %v",
+
+	// ピッカー
+	MsgPickerHint:       "Enter: 選択  Esc: キャンセル",
+	MsgPickerHintMulti:  "Tab: 選択/解除  Enter: 確定  Esc: キャンセル",
+	MsgPickerPrompt:     "worktree",
+	MsgPickerNoWorktree: "選択できるworktreeがありません",
+
+	// ui コマンド
+	MsgUITitle:             "scion — %s",
+	MsgUIHeaderChanges:     "変更",
+	MsgUIHeaderSync:        "同期",
+	MsgUIHeaderAgents:      "エージェント",
+	MsgUILockedMark:        "[ロック]",
+	MsgUIAgentsUnsupported: "未対応",
+	MsgUIHint:              "j/k: 移動  c: 作成  d: 削除  a: エージェント  o: エディタ  v: 差分  l: ロック  r: 更新  q: 終了",
+	MsgUIDiffHint:          "j/k: スクロール  space/b: ページ送り  q: 戻る",
+	MsgUICreatePrompt:      "新しいブランチ名: ",
+	MsgUIClearConfirm:      "%s を削除しますか? [y/N]",
+	MsgUIPressAnyKey:       "何かキーを押すと戻ります",
+	MsgUIMainWorktree:      "メインworktreeには実行できません",
+	MsgUILocked:            "%s をロックしました",
+	MsgUIUnlocked:          "%s のロックを解除しました",
+	MsgUINoDiff:            "変更はありません",
+	MsgUINotTerminal:       "ui コマンドは端末でのみ使用できます",
+	MsgUIAgentNotSet:       "エージェントのコマンドが設定されていません (agent.command)",
+
+	// config コマンド
+	MsgConfigUpdated:          "設定を更新しました: %s = %s",
+	MsgConfigActiveProfile:    "有効なプロファイル: %s",
+	MsgConfigGlobalHeader:     "グローバル設定 (%s):",
+	MsgConfigLocalHeader:      "ローカル設定 (%s):",
+	MsgConfigLocalExists:      "(ローカル設定が存在します)",
+	MsgConfigProfileActive:    "(有効)",
+	MsgConfigConfirmReset:     "設定をデフォルトに戻しますか?",
+	MsgConfigResetDone:        "設定をデフォルトにリセットしました",
+	MsgConfigMigrateFailed:    "%s のマイグレーションに失敗しました: %w",
+	MsgConfigUpToDate:         "%s は最新の形式です (v%d)",
+	MsgConfigWillMigrate:      "%s: v%d → v%d に更新されます",
+	MsgConfigMigrated:         "%s を更新しました: v%d → v%d (バックアップ: %s.bak)",
+	MsgConfigNoFiles:          "設定ファイルが見つかりません",
+	MsgConfigSchemaFailed:     "JSON Schemaの生成に失敗しました: %w",
+	MsgConfigSchemaWritten:    "JSON Schemaを出力しました: %s",
+	MsgConfigNewerVersionLoad: "%s: %v (認識できない設定は無視されます)",
+	MsgConfigOutdated:         "%s は古い形式の設定ファイルです (v%d → v%d)。'scion config migrate' で更新できます",
+
+	// 設定パッケージのエラー
+	MsgMigrationBaseBranch:      "repository.base_branch を git.default_base_branch に統合",
+	MsgInvalidVersion:           "無効なバージョンです: %v",
+	MsgNewerVersion:             "設定ファイルのバージョン %d はこのscionが対応するバージョン %d より新しいです",
+	MsgMigrationFailed:          "マイグレーション (v%d → v%d) に失敗しました: %w",
+	MsgMigrationMissing:         "バージョン %d からのマイグレーションが定義されていません",
+	MsgBackupFailed:             "バックアップの作成に失敗しました: %w",
+	MsgKeyEmpty:                 "キーが空です",
+	MsgKeyInvalid:               "無効なキー形式です: %s",
+	MsgKeyMissingBracket:        "無効なキー形式です: %s (']' がありません)",
+	MsgKeyEmptyIndex:            "無効なキー形式です: %s (空のインデックス)",
+	MsgKeyNotFound:              "キー '%s' が見つかりません",
+	MsgKeyNotSet:                "キー '%s' に値が設定されていません",
+	MsgKeyNotList:               "'%s' はリストではありません",
+	MsgKeyListElement:           "'%s' はリストの要素として無効です ([n] または [+] を使用してください)",
+	MsgKeyAppendOnlySet:         "[+] は値の設定時のみ使用できます",
+	MsgKeyInvalidIndex:          "無効なインデックスです: %s",
+	MsgKeyIndexOutOfRange:       "インデックス %s は範囲外です (要素数: %d)",
+	MsgKeyUnsupportedMap:        "サポートされていないマップ型です: %v",
+	MsgKeyNoNested:              "'%s' にはこれ以上ネストしたキーはありません",
+	MsgValueInvalidDuration:     "無効な期間です: %s (例: 30s, 5m)",
+	MsgValueInvalidBool:         "無効なbool値です: %s",
+	MsgValueInvalidNumber:       "無効な数値です: %s",
+	MsgValueInvalidTOML:         "無効な値です: %s (TOML形式で指定してください: %v)",
+	MsgValueUnsupportedType:     "サポートされていない型です: %v",
+	MsgProfileNotFound:          "プロファイル '%s' が見つかりません",
+	MsgProfileApplyFailed:       "プロファイル '%s' の適用に失敗しました: %w",
+	MsgProfileReservedKey:       "'%s' はプロファイル内で指定できません",
+	MsgProfileLoadFailed:        "プロファイル '%s' の読み込みに失敗しました: %w",
+	MsgProfileSectionNotAllowed: "セクション全体はプロファイルに設定できません。個別のキーを指定してください",
+	MsgRulePatternMissing:       "ルールの pattern が指定されていません",
+	MsgRulePatternInvalid:       "ルールのパターン '%s' が無効です: %w",
+
+	// 設定キーの説明
+	MsgDescConfig:                        "scionの設定ファイル (config.toml)",
+	MsgDescVersion:                       "設定ファイルのバージョン (scion config migrate で更新)",
+	MsgDescRepository:                    "リポジトリ関連の設定",
+	MsgDescWorktree:                      "Worktree関連の設定",
+	MsgDescGit:                           "Git関連の設定",
+	MsgDescUI:                            "UI関連の設定",
+	MsgDescEditor:                        "エディタ設定",
+	MsgDescAgent:                         "AIエージェント設定",
+	MsgDescHooks:                         "フック設定",
+	MsgDescRules:                         "ブランチ名のパターンごとの設定 (定義順に適用)",
+	MsgDescProfiles:                      "名前付きプロファイル (--profile または SCION_PROFILE で選択)",
+	MsgDescRepositoryBaseRepository:      "コマンド対象のリポジトリパス",
+	MsgDescWorktreePlacement:             "worktreeの配置方法 (sibling: リポジトリと同じ階層, per_repo: base_dir/リポジトリ名, inside: リポジトリ内, root: root/ホスト/所有者/リポジトリ名)",
+	MsgDescWorktreeBaseDir:               "worktreeディレクトリのベース名",
+	MsgDescWorktreeRoot:                  "placement = root の場合に worktree を配置するディレクトリ",
+	MsgDescWorktreeDirTemplate:           "worktreeのパスのテンプレート (指定時は placement より優先、相対パスはリポジトリの親ディレクトリ基準、関数: slug, escape, lower)",
+	MsgDescWorktreeAutoCreateDir:         "wtreeディレクトリを自動作成",
+	MsgDescWorktreeCleanupOnBranchDelete: "ブランチ削除時にworktreeも削除",
+	MsgDescWorktreeSparsePaths:           "指定時は cone モードの sparse checkout でこのディレクトリだけをチェックアウト (モノレポ向け)",
+	MsgDescGitDefaultRemote:              "デフォルトのリモート名",
+	MsgDescGitDefaultBaseBranch:          "デフォルトのベースブランチ",
+	MsgDescGitFetchBeforeCreate:          "worktree作成前にfetchを実行",
+	MsgDescGitPushOnCreate:               "作成した新しいブランチをリモートにpushし、上流に設定",
+	MsgDescGitSubmodules:                 "worktree作成後にサブモジュールを初期化・更新 (.gitmodules がある場合)",
+	MsgDescGitSubmoduleReference:         "メインworktreeで初期化済みのサブモジュールを --reference に指定して再ダウンロードを避ける",
+	MsgDescGitLFSPull:                    "worktree作成後に git lfs pull を実行 (.gitattributes で LFS を使用している場合)",
+	MsgDescGitUpstreamMode:               "新しいブランチの上流 (none: 設定しない, push: リモートの同名ブランチ, base: ベースブランチ)",
+	MsgDescGitSyncMode:                   "scion sync でベースを取り込む方法 (rebase: リベース, merge: マージ)",
+	MsgDescGitPullRequestRef:             "scion create --pr で取得するリモートのref (* はプルリクエストの番号、GitLab: refs/merge-requests/*/head)",
+	MsgDescUIColorOutput:                 "カラー出力を有効化",
+	MsgDescUIVerbose:                     "詳細な出力",
+	MsgDescUIConfirmDestructive:          "破壊的操作の確認",
+	MsgDescUILanguage:                    "表示言語 (auto: 環境変数 LC_ALL/LC_MESSAGES/LANG から判定)",
+	MsgDescUIPromptTimeout:               "確認プロンプトの応答を待つ秒数 (0: 無制限)",
+	MsgDescEditorCommand:                 "デフォルトエディタ",
+	MsgDescAgentCommand:                  "scion ui から起動するエージェントのコマンド",
+	MsgDescAgentProcesses:                "実行中のエージェントとして検出するプロセス名",
+	MsgDescHooksPostCreate:               "worktree作成後にworktree内で実行するコマンド",
+	MsgDescHooksPreClear:                 "worktree削除前にworktree内で実行するコマンド",
+	MsgDescHooksPreLand:                  "scion land で取り込む前にworktree内で実行するコマンド (テストなど)",
+	MsgDescRulePattern:                   "対象ブランチのglobパターン (例: hotfix/*)",
+	MsgDescRuleBaseBranch:                "worktreeのブランチを切るベースブランチ",
+	MsgDescRuleRemote:                    "使用するリモート名",
+	MsgDescRulePlacement:                 "worktreeの配置方法",
+	MsgDescRuleBaseDir:                   "worktreeディレクトリのベース名",
+	MsgDescRuleDirTemplate:               "worktreeのパスのテンプレート",
+	MsgDescRuleFetchBeforeCreate:         "worktree作成前にfetchを実行",
+	MsgDescRulePushOnCreate:              "作成した新しいブランチをリモートにpush",
+	MsgDescRuleSparsePaths:               "sparse checkout でチェックアウトするディレクトリ (空のリストで無効化)",
+	MsgDescRuleHooks:                     "このルールで使用するフック (指定時は既存のフックを置き換える)",
+
+	// worktree のディレクトリ
+	MsgLayoutInvalidTemplate:  "worktree.dir_template が無効です: %w",
+	MsgLayoutEmptyPath:        "worktree.dir_template からブランチ '%s' のパスを決定できません",
+	MsgLayoutRepoPath:         "ブランチ '%s' の worktree.dir_template がリポジトリ自身を指しています: %s",
+	MsgLayoutCollision:        "%s はブランチ '%s' が使用しているため、%s を使用します",
+	MsgLayoutStoreFailed:      "worktree の対応表を更新できません: %v",
+	MsgLayoutUnknownPlacement: "worktree.placement '%s' は不明です (使用可能: sibling, per_repo, inside, root)",
+	MsgLayoutExcludeFailed:    "worktree のディレクトリを .git/info/exclude に追加できません: %v",
+
+	// git パッケージのエラー
+	MsgGitRootFailed:           "Gitリポジトリのルートを取得できません: %s",
+	MsgGitCurrentBranchFailed:  "現在のブランチを取得できません: %s",
+	MsgGitHeadFailed:           "HEAD のコミットを取得できません: %s",
+	MsgGitResolveFailed:        "リビジョンのコミットを取得できません: %s",
+	MsgGitUpdateRefFailed:      "ブランチを更新できません: %s",
+	MsgGitPathExists:           "%s は既に存在し、空ではありません",
+	MsgGitBranchCheckedOut:     "ブランチ '%s' は既に %s でチェックアウトされています",
+	MsgGitBaseBranchNotFound:   "ベースブランチ '%s' が見つかりません",
+	MsgGitRevisionNotFound:     "リビジョン '%s' が見つかりません",
+	MsgGitBranchNotFound:       "ブランチ '%s' が見つかりません",
+	MsgGitMkdirFailed:          "worktreeディレクトリの作成に失敗しました: %w",
+	MsgGitWorktreeAddFailed:    "worktreeの作成に失敗しました: %s",
+	MsgGitSparseCheckoutFailed: "sparse checkout の設定に失敗しました: %s",
+	MsgGitSubmoduleFailed:      "サブモジュールの更新に失敗しました: %s",
+	MsgGitLFSPullFailed:        "git lfs pull に失敗しました: %s",
+	MsgGitWorktreeRemoveFailed: "worktreeの削除に失敗しました: %s",
+	MsgGitBranchDeleteFailed:   "ブランチの削除に失敗しました: %s",
+	MsgGitWorktreeListFailed:   "worktreeのリスト取得に失敗しました: %s",
+	MsgGitBranchListFailed:     "ブランチのリスト取得に失敗しました: %s",
+	MsgGitTagListFailed:        "タグのリスト取得に失敗しました: %s",
+	MsgGitRemoteListFailed:     "リモートのリスト取得に失敗しました: %s",
+	MsgGitRemoteURLFailed:      "リモートのURLを取得できません: %s",
+	MsgGitStatusFailed:         "ステータスの確認に失敗しました: %s",
+	MsgGitFetchFailed:          "fetchに失敗しました: %s",
+	MsgGitPushFailed:           "pushに失敗しました: %s",
+	MsgGitSetUpstreamFailed:    "上流を設定できません: %s",
+	MsgGitRebaseFailed:         "リベースに失敗しました: %s",
+	MsgGitMergeFailed:          "マージに失敗しました: %s",
+	MsgGitFastForwardFailed:    "早送りできません: %s",
+	MsgGitCommitFailed:         "コミットに失敗しました: %s",
+	MsgGitDiffFailed:           "差分の取得に失敗しました: %s",
+	MsgGitLockFailed:           "worktreeのロックに失敗しました: %s",
+	MsgGitUnlockFailed:         "worktreeのロック解除に失敗しました: %s",
+
+	// 詳細出力とデバッグ出力
+	MsgDebugCommand:       "%s (%s)",
+	MsgDebugCommandFailed: "%s (%s, %v)",
+	MsgVerboseLoadedFile:  "設定ファイルを読み込みました: %s",
+	MsgVerboseProfile:     "使用するプロファイル: %s",
+	MsgVerboseLocale:      "表示言語: %s",
+	MsgVerboseCreatePlan:  "ベースブランチ: %s, リモート: %s, worktreeディレクトリ: %s",
+}
@@ -0,0 +1,125 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Locale は表示言語を表す
+type Locale string
+
+const (
+	// English は英語
+	English Locale = "en"
+	// Japanese は日本語
+	Japanese Locale = "ja"
+)
+
+// DefaultLocale は環境変数から言語を判定できない場合に使用する言語
+const DefaultLocale = English
+
+// MessageID はメッセージカタログのキー
+// メッセージIDの定数は型なし定数として定義し、cobraのヘルプテキストなど string を受け取る箇所にもそのまま渡せるようにする
+// カタログに存在しないIDは、そのままフォーマット文字列として扱われる
+type MessageID string
+
+// catalogs は言語ごとのメッセージカタログ
+var catalogs = map[Locale]map[MessageID]string{
+	English:  catalogEn,
+	Japanese: catalogJa,
+}
+
+var (
+	mu      sync.RWMutex
+	current = Detect("")
+)
+
+// SetLocale は表示言語を設定する
+func SetLocale(locale Locale) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = locale
+}
+
+// CurrentLocale は現在の表示言語を返す
+func CurrentLocale() Locale {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Supported は対応している言語の一覧を返す
+func Supported() []Locale {
+	return []Locale{English, Japanese}
+}
+
+// Parse は言語名またはロケール文字列（ja_JP.UTF-8 など）を Locale に変換する
+// 対応していない言語の場合は false を返す
+func Parse(value string) (Locale, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, "_.@-"); i >= 0 {
+		value = value[:i]
+	}
+
+	switch value {
+	case "en", "c", "posix":
+		return English, true
+	case "ja":
+		return Japanese, true
+	}
+	return "", false
+}
+
+// Detect は表示言語を決定する
+// configured（ui.language）が "auto" または空の場合は LC_ALL、LC_MESSAGES、LANG の順に環境変数を参照する
+func Detect(configured string) Locale {
+	if configured != "" && configured != "auto" {
+		if locale, ok := Parse(configured); ok {
+			return locale
+		}
+	}
+
+	// POSIXの規則に従い、最初に設定されている環境変数のみを使用する
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		if locale, ok := Parse(value); ok {
+			return locale
+		}
+		break
+	}
+	return DefaultLocale
+}
+
+// lookup は現在の言語のメッセージを返す
+// 現在の言語にない場合は英語、英語にもない場合はIDをそのまま返す
+func lookup(id MessageID) string {
+	locale := CurrentLocale()
+	if msg, ok := catalogs[locale][id]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[DefaultLocale][id]; ok {
+		return msg
+	}
+	return string(id)
+}
+
+// Text はメッセージを書式化せずに返す
+func Text(id MessageID) string {
+	return lookup(id)
+}
+
+// T はメッセージを現在の言語に翻訳し、引数で書式化して返す
+func T(id MessageID, args ...interface{}) string {
+	return fmt.Sprintf(lookup(id), args...)
+}
+
+// Errorf は翻訳したメッセージからエラーを生成する
+// fmt.Errorf と同様に %w でエラーをラップできる
+func Errorf(id MessageID, args ...interface{}) error {
+	return fmt.Errorf(lookup(id), args...)
+}
@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for id := range catalogEn {
+		if _, ok := catalogJa[id]; !ok {
+			t.Errorf("message %q is missing in the Japanese catalog", id)
+		}
+	}
+	for id := range catalogJa {
+		if _, ok := catalogEn[id]; !ok {
+			t.Errorf("message %q is missing in the English catalog", id)
+		}
+	}
+}
+
+func TestCatalogsHaveSameVerbs(t *testing.T) {
+	for id, en := range catalogEn {
+		ja, ok := catalogJa[id]
+		if !ok {
+			continue
+		}
+		enVerbs := verbPattern.FindAllString(en, -1)
+		jaVerbs := verbPattern.FindAllString(ja, -1)
+		if len(enVerbs) != len(jaVerbs) {
+			t.Errorf("message %q: verbs differ (en: %v, ja: %v)", id, enVerbs, jaVerbs)
+			continue
+		}
+		for i := range enVerbs {
+			if enVerbs[i] != jaVerbs[i] {
+				t.Errorf("message %q: verbs differ (en: %v, ja: %v)", id, enVerbs, jaVerbs)
+				break
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   Locale
+		wantOK bool
+	}{
+		{"en", English, true},
+		{"ja", Japanese, true},
+		{"ja_JP.UTF-8", Japanese, true},
+		{"en_US.UTF-8", English, true},
+		{"C", English, true},
+		{"POSIX", English, true},
+		{"fr_FR.UTF-8", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Parse(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Parse(%q) = (%q, %v), want (%q, %v)", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		lcAll      string
+		lcMessages string
+		lang       string
+		want       Locale
+	}{
+		{"configured", "ja", "en_US.UTF-8", "", "", Japanese},
+		{"auto uses LANG", "auto", "", "", "ja_JP.UTF-8", Japanese},
+		{"LC_ALL takes precedence", "", "en_US.UTF-8", "", "ja_JP.UTF-8", English},
+		{"LC_MESSAGES before LANG", "", "", "ja_JP.UTF-8", "en_US.UTF-8", Japanese},
+		{"unsupported falls back", "", "fr_FR.UTF-8", "", "ja_JP.UTF-8", DefaultLocale},
+		{"nothing set", "", "", "", "", DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", tt.lcMessages)
+			t.Setenv("LANG", tt.lang)
+
+			if got := Detect(tt.configured); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.configured, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	original := CurrentLocale()
+	defer SetLocale(original)
+
+	SetLocale(Japanese)
+	if got := T(MsgCreatePath, "/tmp/x"); got != "パス: /tmp/x" {
+		t.Errorf("unexpected Japanese message: %q", got)
+	}
+
+	SetLocale(English)
+	if got := T(MsgCreatePath, "/tmp/x"); got != "Path: /tmp/x" {
+		t.Errorf("unexpected English message: %q", got)
+	}
+
+	// カタログにないIDはそのままフォーマット文字列として扱う
+	if got := T("plain %s", "text"); got != "plain text" {
+		t.Errorf("unexpected fallback message: %q", got)
+	}
+}
@@ -0,0 +1,401 @@
+package i18n
+
+// コマンドのヘルプテキスト
+const (
+	CmdRootShort          = "cmd.root.short"
+	CmdRootLong           = "cmd.root.long"
+	CmdCreateShort        = "cmd.create.short"
+	CmdCreateLong         = "cmd.create.long"
+	CmdClearShort         = "cmd.clear.short"
+	CmdClearLong          = "cmd.clear.long"
+	CmdConfigShort        = "cmd.config.short"
+	CmdConfigLong         = "cmd.config.long"
+	CmdConfigGetShort     = "cmd.config.get.short"
+	CmdConfigSetShort     = "cmd.config.set.short"
+	CmdConfigListShort    = "cmd.config.list.short"
+	CmdConfigResetShort   = "cmd.config.reset.short"
+	CmdConfigEditShort    = "cmd.config.edit.short"
+	CmdConfigSchemaShort  = "cmd.config.schema.short"
+	CmdConfigSchemaLong   = "cmd.config.schema.long"
+	CmdConfigMigrateShort = "cmd.config.migrate.short"
+	CmdConfigMigrateLong  = "cmd.config.migrate.long"
+	CmdListShort          = "cmd.list.short"
+	CmdListLong           = "cmd.list.long"
+	CmdCdShort            = "cmd.cd.short"
+	CmdCdLong             = "cmd.cd.long"
+	CmdOpenShort          = "cmd.open.short"
+	CmdOpenLong           = "cmd.open.long"
+	CmdUIShort            = "cmd.ui.short"
+	CmdUILong             = "cmd.ui.long"
+	CmdLogShort           = "cmd.log.short"
+	CmdLogLong            = "cmd.log.long"
+	CmdSyncShort          = "cmd.sync.short"
+	CmdSyncLong           = "cmd.sync.long"
+	CmdLandShort          = "cmd.land.short"
+	CmdLandLong           = "cmd.land.long"
+)
+
+// フラグの説明
+const (
+	FlagRootConfig         = "flag.root.config"
+	FlagRootProfile        = "flag.root.profile"
+	FlagRootVersion        = "flag.root.version"
+	FlagRootNoColor        = "flag.root.no_color"
+	FlagRootVerbose        = "flag.root.verbose"
+	FlagRootQuiet          = "flag.root.quiet"
+	FlagRootDebug          = "flag.root.debug"
+	FlagRootYes            = "flag.root.yes"
+	FlagRootErrorFormat    = "flag.root.error_format"
+	FlagCreateBase         = "flag.create.base"
+	FlagCreateRemote       = "flag.create.remote"
+	FlagCreateForce        = "flag.create.force"
+	FlagCreateTrack        = "flag.create.track"
+	FlagCreatePR           = "flag.create.pr"
+	FlagCreateDetach       = "flag.create.detach"
+	FlagCreatePush         = "flag.create.push"
+	FlagCreateSparse       = "flag.create.sparse"
+	FlagCreateNoSparse     = "flag.create.no_sparse"
+	FlagClearForce         = "flag.clear.force"
+	FlagClearAll           = "flag.clear.all"
+	FlagClearKeepBranch    = "flag.clear.keep_branch"
+	FlagConfigGlobal       = "flag.config.global"
+	FlagConfigLocal        = "flag.config.local"
+	FlagConfigMigrateDry   = "flag.config.migrate.dry_run"
+	FlagConfigSchemaOutput = "flag.config.schema.output"
+	FlagListFormat         = "flag.list.format"
+	FlagLogBranch          = "flag.log.branch"
+	FlagLogAction          = "flag.log.action"
+	FlagLogLimit           = "flag.log.limit"
+	FlagLogAllRepos        = "flag.log.all_repos"
+	FlagLogFormat          = "flag.log.format"
+	FlagSyncAll            = "flag.sync.all"
+	FlagSyncMode           = "flag.sync.mode"
+	FlagSyncNoFetch        = "flag.sync.no_fetch"
+	FlagLandSquash         = "flag.land.squash"
+	FlagLandRebase         = "flag.land.rebase"
+	FlagLandFFOnly         = "flag.land.ff_only"
+	FlagLandClear          = "flag.land.clear"
+	FlagLandNoVerify       = "flag.land.no_verify"
+)
+
+// 共通メッセージ
+const (
+	MsgNotGitRepository          = "common.not_git_repository"
+	MsgCancelled                 = "common.cancelled"
+	MsgConfigLoadFailed          = "common.config_load_failed"
+	MsgForceHint                 = "common.force_hint"
+	MsgConfirmNotInteractive     = "common.confirm_not_interactive"
+	MsgConfirmTimeout            = "common.confirm_timeout"
+	MsgInvalidErrorFormat        = "common.invalid_error_format"
+	MsgInvalidFormat             = "common.invalid_format"
+	MsgBranchRequired            = "common.branch_required"
+	MsgWorktreeNotFoundForBranch = "common.worktree_not_found_for_branch"
+	MsgEditorNotSet              = "common.editor_not_set"
+	MsgOpenOpening               = "open.opening"
+)
+
+// create コマンド
+const (
+	MsgCreateApplyingRule        = "create.applying_rule"
+	MsgCreateFetching            = "create.fetching"
+	MsgCreateFetchFailed         = "create.fetch_failed"
+	MsgCreateWorktreeExists      = "create.worktree_exists"
+	MsgCreateBranchExists        = "create.branch_exists"
+	MsgCreateRemovingExisting    = "create.removing_existing"
+	MsgCreateRemoveFailed        = "create.remove_failed"
+	MsgCreateCreating            = "create.creating"
+	MsgCreateCreatedDir          = "create.created_dir"
+	MsgCreateSparse              = "create.sparse"
+	MsgCreateUpdatingSubmodules  = "create.updating_submodules"
+	MsgCreateSubmodulesUpdated   = "create.submodules_updated"
+	MsgCreateSubmodulesFailed    = "create.submodules_failed"
+	MsgCreatePullingLFS          = "create.pulling_lfs"
+	MsgCreateLFSPulled           = "create.lfs_pulled"
+	MsgCreateLFSFailed           = "create.lfs_failed"
+	MsgCreateLFSNotInstalled     = "create.lfs_not_installed"
+	MsgCreateCreatedBranch       = "create.created_branch"
+	MsgCreateCheckedOutBranch    = "create.checked_out_branch"
+	MsgCreatePath                = "create.path"
+	MsgCreateBranchRequired      = "create.branch_required"
+	MsgCreateNotRemoteBranch     = "create.not_remote_branch"
+	MsgCreateRemoteNotFound      = "create.remote_branch_not_found"
+	MsgCreateTracking            = "create.tracking"
+	MsgCreateFetchingPR          = "create.fetching_pr"
+	MsgCreatePRRefInvalid        = "create.pr_ref_invalid"
+	MsgCreatePRUpdated           = "create.pr_updated"
+	MsgCreatePRDiverged          = "create.pr_diverged"
+	MsgCreateCreatingDetached    = "create.creating_detached"
+	MsgCreateDetachedAt          = "create.detached_at"
+	MsgCreatePushing             = "create.pushing"
+	MsgCreatePushed              = "create.pushed"
+	MsgCreatePushFailed          = "create.push_failed"
+	MsgCreateUpstreamSet         = "create.upstream_set"
+	MsgCreateUpstreamFailed      = "create.upstream_failed"
+	MsgCreateUpstreamNoBase      = "create.upstream_no_base"
+	MsgCreateUpstreamModeInvalid = "create.upstream_mode_invalid"
+	MsgHookRunning               = "hooks.running"
+	MsgHookFailed                = "hooks.failed"
+)
+
+// clear コマンド
+const (
+	MsgClearBranchRequired     = "clear.branch_required"
+	MsgClearNothingToRemove    = "clear.nothing_to_remove"
+	MsgClearTargets            = "clear.targets"
+	MsgClearConfirmAll         = "clear.confirm_all"
+	MsgClearConfirm            = "clear.confirm"
+	MsgClearConfirmSelected    = "clear.confirm_selected"
+	MsgClearRemovedAll         = "clear.removed_all"
+	MsgClearWorktreeNotFound   = "clear.worktree_not_found"
+	MsgClearStatusCheckFailed  = "clear.status_check_failed"
+	MsgClearUncommittedChanges = "clear.uncommitted_changes"
+	MsgClearRemoving           = "clear.removing"
+	MsgClearRemovedWorktree    = "clear.removed_worktree"
+	MsgClearBranchDeleteFailed = "clear.branch_delete_failed"
+	MsgClearDeletedBranch      = "clear.deleted_branch"
+	MsgClearSomeFailed         = "clear.some_failed"
+)
+
+// list コマンド
+const (
+	MsgListHeaderBranch = "list.header.branch"
+	MsgListHeaderHead   = "list.header.head"
+	MsgListHeaderPath   = "list.header.path"
+	MsgListBare         = "list.bare"
+	MsgListDetached     = "list.detached"
+)
+
+// log コマンド
+const (
+	MsgLogHeaderTime          = "log.header.time"
+	MsgLogHeaderAction        = "log.header.action"
+	MsgLogHeaderOutcome       = "log.header.outcome"
+	MsgLogEmpty               = "log.empty"
+	MsgLogReadFailed          = "log.read_failed"
+	MsgSyncFetching           = "sync.fetching"
+	MsgSyncFetchFailed        = "sync.fetch_failed"
+	MsgSyncRunning            = "sync.running"
+	MsgSyncNothing            = "sync.nothing"
+	MsgSyncHeaderBase         = "sync.header.base"
+	MsgSyncHeaderResult       = "sync.header.result"
+	MsgSyncUpdated            = "sync.updated"
+	MsgSyncUpToDate           = "sync.up_to_date"
+	MsgSyncSkippedDirty       = "sync.skipped_dirty"
+	MsgSyncSkippedDetached    = "sync.skipped_detached"
+	MsgSyncSkippedSelf        = "sync.skipped_self"
+	MsgSyncConflict           = "sync.conflict"
+	MsgSyncFailed             = "sync.failed"
+	MsgSyncConflicts          = "sync.conflicts"
+	MsgSyncSomeFailed         = "sync.some_failed"
+	MsgSyncModeInvalid        = "sync.mode_invalid"
+	MsgLandMainWorktree       = "land.main_worktree"
+	MsgLandUncommittedChanges = "land.uncommitted_changes"
+	MsgLandSameBase           = "land.same_base"
+	MsgLandBaseNotBranch      = "land.base_not_branch"
+	MsgLandBaseDirty          = "land.base_dirty"
+	MsgLandTemporary          = "land.temporary"
+	MsgLandTemporaryFailed    = "land.temporary_failed"
+	MsgLandRebasing           = "land.rebasing"
+	MsgLandLanding            = "land.landing"
+	MsgLandAlreadyMerged      = "land.already_merged"
+	MsgLandLanded             = "land.landed"
+	MsgLandConflict           = "land.conflict"
+	MsgAuditWriteFailed       = "log.audit_write_failed"
+)
+
+// ピッカー
+const (
+	MsgPickerHint       = "picker.hint"
+	MsgPickerHintMulti  = "picker.hint_multi"
+	MsgPickerPrompt     = "picker.prompt"
+	MsgPickerNoWorktree = "picker.no_worktree"
+)
+
+// ui コマンド
+const (
+	MsgUITitle             = "ui.title"
+	MsgUIHeaderChanges     = "ui.header.changes"
+	MsgUIHeaderSync        = "ui.header.sync"
+	MsgUIHeaderAgents      = "ui.header.agents"
+	MsgUILockedMark        = "ui.locked_mark"
+	MsgUIAgentsUnsupported = "ui.agents_unsupported"
+	MsgUIHint              = "ui.hint"
+	MsgUIDiffHint          = "ui.diff_hint"
+	MsgUICreatePrompt      = "ui.create_prompt"
+	MsgUIClearConfirm      = "ui.clear_confirm"
+	MsgUIPressAnyKey       = "ui.press_any_key"
+	MsgUIMainWorktree      = "ui.main_worktree"
+	MsgUILocked            = "ui.locked"
+	MsgUIUnlocked          = "ui.unlocked"
+	MsgUINoDiff            = "ui.no_diff"
+	MsgUINotTerminal       = "ui.not_terminal"
+	MsgUIAgentNotSet       = "ui.agent_not_set"
+)
+
+// config コマンド
+const (
+	MsgConfigUpdated          = "config.updated"
+	MsgConfigActiveProfile    = "config.active_profile"
+	MsgConfigGlobalHeader     = "config.global_header"
+	MsgConfigLocalHeader      = "config.local_header"
+	MsgConfigLocalExists      = "config.local_exists"
+	MsgConfigProfileActive    = "config.profile_active"
+	MsgConfigConfirmReset     = "config.confirm_reset"
+	MsgConfigResetDone        = "config.reset_done"
+	MsgConfigMigrateFailed    = "config.migrate_failed"
+	MsgConfigUpToDate         = "config.up_to_date"
+	MsgConfigWillMigrate      = "config.will_migrate"
+	MsgConfigMigrated         = "config.migrated"
+	MsgConfigNoFiles          = "config.no_files"
+	MsgConfigSchemaFailed     = "config.schema_failed"
+	MsgConfigSchemaWritten    = "config.schema_written"
+	MsgConfigNewerVersionLoad = "config.newer_version_load"
+	MsgConfigOutdated         = "config.outdated"
+)
+
+// 設定パッケージのエラー
+const (
+	MsgMigrationBaseBranch      = "config.migration.base_branch"
+	MsgInvalidVersion           = "config.invalid_version"
+	MsgNewerVersion             = "config.newer_version"
+	MsgMigrationFailed          = "config.migration_failed"
+	MsgMigrationMissing         = "config.migration_missing"
+	MsgBackupFailed             = "config.backup_failed"
+	MsgKeyEmpty                 = "config.key_empty"
+	MsgKeyInvalid               = "config.key_invalid"
+	MsgKeyMissingBracket        = "config.key_missing_bracket"
+	MsgKeyEmptyIndex            = "config.key_empty_index"
+	MsgKeyNotFound              = "config.key_not_found"
+	MsgKeyNotSet                = "config.key_not_set"
+	MsgKeyNotList               = "config.key_not_list"
+	MsgKeyListElement           = "config.key_list_element"
+	MsgKeyAppendOnlySet         = "config.key_append_only_set"
+	MsgKeyInvalidIndex          = "config.key_invalid_index"
+	MsgKeyIndexOutOfRange       = "config.key_index_out_of_range"
+	MsgKeyUnsupportedMap        = "config.key_unsupported_map"
+	MsgKeyNoNested              = "config.key_no_nested"
+	MsgValueInvalidDuration     = "config.value_invalid_duration"
+	MsgValueInvalidBool         = "config.value_invalid_bool"
+	MsgValueInvalidNumber       = "config.value_invalid_number"
+	MsgValueInvalidTOML         = "config.value_invalid_toml"
+	MsgValueUnsupportedType     = "config.value_unsupported_type"
+	MsgProfileNotFound          = "config.profile_not_found"
+	MsgProfileApplyFailed       = "config.profile_apply_failed"
+	MsgProfileReservedKey       = "config.profile_reserved_key"
+	MsgProfileLoadFailed        = "config.profile_load_failed"
+	MsgProfileSectionNotAllowed = "config.profile_section_not_allowed"
+	MsgRulePatternMissing       = "config.rule_pattern_missing"
+	MsgRulePatternInvalid       = "config.rule_pattern_invalid"
+)
+
+// 設定キーの説明（config.desc.<セクション>.<キー>、セクションは構造体の型名から導出する）
+const (
+	MsgDescConfig                        = "config.desc"
+	MsgDescVersion                       = "config.desc.version"
+	MsgDescRepository                    = "config.desc.repository"
+	MsgDescWorktree                      = "config.desc.worktree"
+	MsgDescGit                           = "config.desc.git"
+	MsgDescUI                            = "config.desc.ui"
+	MsgDescEditor                        = "config.desc.editor"
+	MsgDescAgent                         = "config.desc.agent"
+	MsgDescHooks                         = "config.desc.hooks"
+	MsgDescRules                         = "config.desc.rules"
+	MsgDescProfiles                      = "config.desc.profiles"
+	MsgDescRepositoryBaseRepository      = "config.desc.repository.base_repository"
+	MsgDescWorktreePlacement             = "config.desc.worktree.placement"
+	MsgDescWorktreeBaseDir               = "config.desc.worktree.base_dir"
+	MsgDescWorktreeRoot                  = "config.desc.worktree.root"
+	MsgDescWorktreeDirTemplate           = "config.desc.worktree.dir_template"
+	MsgDescWorktreeAutoCreateDir         = "config.desc.worktree.auto_create_dir"
+	MsgDescWorktreeCleanupOnBranchDelete = "config.desc.worktree.cleanup_on_branch_delete"
+	MsgDescWorktreeSparsePaths           = "config.desc.worktree.sparse_paths"
+	MsgDescGitDefaultRemote              = "config.desc.git.default_remote"
+	MsgDescGitDefaultBaseBranch          = "config.desc.git.default_base_branch"
+	MsgDescGitFetchBeforeCreate          = "config.desc.git.fetch_before_create"
+	MsgDescGitPushOnCreate               = "config.desc.git.push_on_create"
+	MsgDescGitSubmodules                 = "config.desc.git.submodules"
+	MsgDescGitSubmoduleReference         = "config.desc.git.submodule_reference"
+	MsgDescGitLFSPull                    = "config.desc.git.lfs_pull"
+	MsgDescGitUpstreamMode               = "config.desc.git.upstream_mode"
+	MsgDescGitSyncMode                   = "config.desc.git.sync_mode"
+	MsgDescGitPullRequestRef             = "config.desc.git.pull_request_ref"
+	MsgDescUIColorOutput                 = "config.desc.ui.color_output"
+	MsgDescUIVerbose                     = "config.desc.ui.verbose"
+	MsgDescUIConfirmDestructive          = "config.desc.ui.confirm_destructive"
+	MsgDescUILanguage                    = "config.desc.ui.language"
+	MsgDescUIPromptTimeout               = "config.desc.ui.prompt_timeout"
+	MsgDescEditorCommand                 = "config.desc.editor.command"
+	MsgDescAgentCommand                  = "config.desc.agent.command"
+	MsgDescAgentProcesses                = "config.desc.agent.processes"
+	MsgDescHooksPostCreate               = "config.desc.hooks.post_create"
+	MsgDescHooksPreClear                 = "config.desc.hooks.pre_clear"
+	MsgDescHooksPreLand                  = "config.desc.hooks.pre_land"
+	MsgDescRulePattern                   = "config.desc.rule.pattern"
+	MsgDescRuleBaseBranch                = "config.desc.rule.base_branch"
+	MsgDescRuleRemote                    = "config.desc.rule.remote"
+	MsgDescRulePlacement                 = "config.desc.rule.placement"
+	MsgDescRuleBaseDir                   = "config.desc.rule.base_dir"
+	MsgDescRuleDirTemplate               = "config.desc.rule.dir_template"
+	MsgDescRuleFetchBeforeCreate         = "config.desc.rule.fetch_before_create"
+	MsgDescRulePushOnCreate              = "config.desc.rule.push_on_create"
+	MsgDescRuleSparsePaths               = "config.desc.rule.sparse_paths"
+	MsgDescRuleHooks                     = "config.desc.rule.hooks"
+)
+
+// worktree のディレクトリ
+const (
+	MsgLayoutInvalidTemplate  = "layout.invalid_template"
+	MsgLayoutEmptyPath        = "layout.empty_path"
+	MsgLayoutRepoPath         = "layout.repo_path"
+	MsgLayoutCollision        = "layout.collision"
+	MsgLayoutStoreFailed      = "layout.store_failed"
+	MsgLayoutUnknownPlacement = "layout.unknown_placement"
+	MsgLayoutExcludeFailed    = "layout.exclude_failed"
+)
+
+// git パッケージのエラー
+const (
+	MsgGitRootFailed           = "git.root_failed"
+	MsgGitCurrentBranchFailed  = "git.current_branch_failed"
+	MsgGitHeadFailed           = "git.head_failed"
+	MsgGitResolveFailed        = "git.resolve_failed"
+	MsgGitUpdateRefFailed      = "git.update_ref_failed"
+	MsgGitPathExists           = "git.path_exists"
+	MsgGitBranchCheckedOut     = "git.branch_checked_out"
+	MsgGitBaseBranchNotFound   = "git.base_branch_not_found"
+	MsgGitRevisionNotFound     = "git.revision_not_found"
+	MsgGitBranchNotFound       = "git.branch_not_found"
+	MsgGitMkdirFailed          = "git.mkdir_failed"
+	MsgGitWorktreeAddFailed    = "git.worktree_add_failed"
+	MsgGitSparseCheckoutFailed = "git.sparse_checkout_failed"
+	MsgGitSubmoduleFailed      = "git.submodule_failed"
+	MsgGitLFSPullFailed        = "git.lfs_pull_failed"
+	MsgGitWorktreeRemoveFailed = "git.worktree_remove_failed"
+	MsgGitBranchDeleteFailed   = "git.branch_delete_failed"
+	MsgGitWorktreeListFailed   = "git.worktree_list_failed"
+	MsgGitBranchListFailed     = "git.branch_list_failed"
+	MsgGitTagListFailed        = "git.tag_list_failed"
+	MsgGitRemoteListFailed     = "git.remote_list_failed"
+	MsgGitRemoteURLFailed      = "git.remote_url_failed"
+	MsgGitStatusFailed         = "git.status_failed"
+	MsgGitFetchFailed          = "git.fetch_failed"
+	MsgGitPushFailed           = "git.push_failed"
+	MsgGitSetUpstreamFailed    = "git.set_upstream_failed"
+	MsgGitRebaseFailed         = "git.rebase_failed"
+	MsgGitMergeFailed          = "git.merge_failed"
+	MsgGitFastForwardFailed    = "git.fast_forward_failed"
+	MsgGitCommitFailed         = "git.commit_failed"
+	MsgGitDiffFailed           = "git.diff_failed"
+	MsgGitLockFailed           = "git.lock_failed"
+	MsgGitUnlockFailed         = "git.unlock_failed"
+)
+
+// 詳細出力とデバッグ出力
+const (
+	MsgDebugCommand       = "debug.command"
+	MsgDebugCommandFailed = "debug.command_failed"
+	MsgVerboseLoadedFile  = "verbose.loaded_file"
+	MsgVerboseProfile     = "verbose.profile"
+	MsgVerboseLocale      = "verbose.locale"
+	MsgVerboseCreatePlan  = "verbose.create_plan"
+)
@@ -0,0 +1,104 @@
+package keys
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind はキー入力の種類
+type Kind int
+
+const (
+	// Rune は文字の入力
+	Rune Kind = iota
+	// Up は上矢印、Ctrl-P、Ctrl-K
+	Up
+	// Down は下矢印、Ctrl-N
+	Down
+	// PageUp は PageUp キー
+	PageUp
+	// PageDown は PageDown キー
+	PageDown
+	// Enter は Enter キー
+	Enter
+	// Tab は Tab キー
+	Tab
+	// Backspace は Backspace キー
+	Backspace
+	// Clear は Ctrl-U（入力のクリア）
+	Clear
+	// Escape は単独の ESC キー
+	Escape
+	// Interrupt は Ctrl-C、Ctrl-G
+	Interrupt
+)
+
+// Event はキー入力
+type Event struct {
+	Kind Kind
+	// Rune は Kind が Rune の場合の入力文字
+	Rune rune
+}
+
+// Parse は raw モードの端末から読み込んだバイト列をキー入力に変換する
+// 認識できないエスケープシーケンスと制御文字は無視する
+func Parse(b []byte) []Event {
+	var events []Event
+	for i := 0; i < len(b); {
+		c := b[i]
+		switch {
+		case c == 0x1b:
+			// 単独の ESC は Escape、それ以外はエスケープシーケンス
+			if i+1 >= len(b) {
+				events = append(events, Event{Kind: Escape})
+				i++
+				continue
+			}
+			if b[i+1] != '[' && b[i+1] != 'O' {
+				i++
+				continue
+			}
+			j := i + 2
+			for j < len(b) && (b[j] < 0x40 || b[j] > 0x7e) {
+				j++
+			}
+			if j < len(b) {
+				switch {
+				case b[j] == 'A':
+					events = append(events, Event{Kind: Up})
+				case b[j] == 'B':
+					events = append(events, Event{Kind: Down})
+				case b[j] == '~' && string(b[i+2:j]) == "5":
+					events = append(events, Event{Kind: PageUp})
+				case b[j] == '~' && string(b[i+2:j]) == "6":
+					events = append(events, Event{Kind: PageDown})
+				}
+			}
+			i = j + 1
+			continue
+		case c == 0x03 || c == 0x07: // Ctrl-C, Ctrl-G
+			events = append(events, Event{Kind: Interrupt})
+		case c == '\r' || c == '\n':
+			events = append(events, Event{Kind: Enter})
+		case c == '\t':
+			events = append(events, Event{Kind: Tab})
+		case c == 0x7f || c == 0x08:
+			events = append(events, Event{Kind: Backspace})
+		case c == 0x15: // Ctrl-U
+			events = append(events, Event{Kind: Clear})
+		case c == 0x10 || c == 0x0b: // Ctrl-P, Ctrl-K
+			events = append(events, Event{Kind: Up})
+		case c == 0x0e: // Ctrl-N
+			events = append(events, Event{Kind: Down})
+		case c >= 0x20:
+			r, size := utf8.DecodeRune(b[i:])
+			if r != utf8.RuneError && unicode.IsPrint(r) {
+				events = append(events, Event{Kind: Rune, Rune: r})
+			}
+			i += size
+			continue
+		}
+		i++
+	}
+	return events
+}
@@ -0,0 +1,35 @@
+package keys
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	events := Parse([]byte("a\x1b[B\x1b[A\x1b[5~\x1b[6~\t\x7f\r\x03\x15"))
+	want := []Kind{Rune, Down, Up, PageUp, PageDown, Tab, Backspace, Enter, Interrupt, Clear}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d: %v", len(want), len(events), events)
+	}
+	for i, ev := range events {
+		if ev.Kind != want[i] {
+			t.Errorf("event %d: kind = %d, want %d", i, ev.Kind, want[i])
+		}
+	}
+}
+
+func TestParseEscape(t *testing.T) {
+	// 単独の ESC
+	if events := Parse([]byte{0x1b}); len(events) != 1 || events[0].Kind != Escape {
+		t.Errorf("expected lone ESC, got %v", events)
+	}
+
+	// 認識できないシーケンスは無視する
+	if events := Parse([]byte("\x1b[1;5C")); len(events) != 0 {
+		t.Errorf("expected unknown sequence to be ignored, got %v", events)
+	}
+}
+
+func TestParseMultibyte(t *testing.T) {
+	events := Parse([]byte("あい"))
+	if len(events) != 2 || events[0].Rune != 'あ' || events[1].Rune != 'い' {
+		t.Errorf("unexpected events: %v", events)
+	}
+}
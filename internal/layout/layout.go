@@ -0,0 +1,141 @@
+package layout
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// DefaultTemplate は sibling 配置のテンプレート
+// メインworktreeの親ディレクトリの base_dir 以下に、ブランチ名の / を - に置き換えた名前で作成する
+const DefaultTemplate = "{{.BaseDir}}/{{.Branch | slug}}"
+
+// Data はディレクトリのテンプレートに渡される値
+type Data struct {
+	// Repo はメインworktreeのディレクトリ名
+	Repo string
+	// RepoPath はメインworktreeの絶対パス
+	RepoPath string
+	// Branch はブランチ名
+	Branch string
+	// BaseDir は worktree.base_dir の値
+	BaseDir string
+	// Root は worktree.root の値
+	Root string
+	// Host はデフォルトのリモートのホスト名（リモートがない場合は local）
+	Host string
+	// Owner はデフォルトのリモートのリポジトリの所有者
+	Owner string
+}
+
+// templateFuncs はディレクトリのテンプレートで使用できる関数
+var templateFuncs = template.FuncMap{
+	"slug":   Slug,
+	"escape": Escape,
+	"lower":  strings.ToLower,
+}
+
+// Path はテンプレートからブランチの worktree のパスを返す
+// 相対パスはメインworktreeの親ディレクトリを基準とし、先頭の ~ はホームディレクトリに展開する
+func Path(tmpl string, data Data) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+	t, err := template.New("dir_template").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return "", i18n.Errorf(i18n.MsgLayoutInvalidTemplate, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", i18n.Errorf(i18n.MsgLayoutInvalidTemplate, err)
+	}
+
+	path := strings.TrimSpace(buf.String())
+	if path == "" {
+		return "", i18n.Errorf(i18n.MsgLayoutEmptyPath, data.Branch)
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(data.RepoPath), path)
+	}
+	path = filepath.Clean(path)
+
+	if path == filepath.Clean(data.RepoPath) {
+		return "", i18n.Errorf(i18n.MsgLayoutRepoPath, data.Branch, path)
+	}
+	return path, nil
+}
+
+// Slug はブランチ名をディレクトリ名として使える文字列に変換する
+// 英数字と . _ - 以外の文字（/ や : 、空白など）は - に置き換え、先頭の . は取り除く
+// 異なるブランチが同じ名前になることがあるため、元のブランチ名は保存した対応表から求める
+func Slug(branch string) string {
+	var b strings.Builder
+	for _, r := range branch {
+		if isSafeRune(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	slug := strings.TrimLeft(b.String(), ".")
+	if slug == "" {
+		return "_"
+	}
+	return slug
+}
+
+// Escape はブランチ名を元に戻せる形でディレクトリ名に変換する
+// 英数字と . _ - 以外の文字は %XX の形式で符号化し、先頭の . も符号化する
+// 例: feature/a-b → feature%2Fa-b
+func Escape(branch string) string {
+	var b strings.Builder
+	for i, r := range branch {
+		if isSafeRune(r) && !(i == 0 && r == '.') {
+			b.WriteRune(r)
+			continue
+		}
+		var buf [utf8.UTFMax]byte
+		for _, c := range buf[:utf8.EncodeRune(buf[:], r)] {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// Unescape は Escape で変換したディレクトリ名からブランチ名を返す
+func Unescape(name string) (string, error) {
+	return url.PathUnescape(name)
+}
+
+// isSafeRune はディレクトリ名にそのまま使える文字かどうかを返す
+func isSafeRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_' || r == '-'
+}
+
+// Unique は path が使用済みの場合に、末尾に -2, -3... を付けた未使用のパスを返す
+func Unique(path string, taken func(path string) bool) string {
+	if !taken(path) {
+		return path
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", path, i)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"feature/login", "feature-login"},
+		{"feature/a-b", "feature-a-b"},
+		{"fix: bad name", "fix--bad-name"},
+		{"release/v1.2", "release-v1.2"},
+		{"..", "_"},
+		{"機能/ログイン", "機能-ログイン"},
+	}
+	for _, tt := range tests {
+		if got := Slug(tt.branch); got != tt.want {
+			t.Errorf("Slug(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeRoundTrip(t *testing.T) {
+	for _, branch := range []string{"feature/a-b", "feature-a/b", "fix: 100%", ".hidden", "機能/ログイン"} {
+		escaped := Escape(branch)
+		if filepath.Base(escaped) != escaped || escaped == ".." || escaped[0] == '.' {
+			t.Errorf("Escape(%q) = %q is not a safe directory name", branch, escaped)
+		}
+		got, err := Unescape(escaped)
+		if err != nil || got != branch {
+			t.Errorf("Unescape(%q) = %q, %v, want %q", escaped, got, err, branch)
+		}
+	}
+	if Escape("feature/a-b") == Escape("feature-a/b") {
+		t.Error("expected distinct escapes for different branches")
+	}
+}
+
+func TestPath(t *testing.T) {
+	data := Data{Repo: "app", RepoPath: "/src/app", Branch: "feature/login", BaseDir: "wtree"}
+
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{"", "/src/wtree/feature-login"},
+		{"{{.Repo}}-trees/{{.Branch | escape}}", "/src/app-trees/feature%2Flogin"},
+		{"/tmp/{{.Repo}}/{{.Branch | slug | lower}}", "/tmp/app/feature-login"},
+		{"{{.RepoPath}}/.worktrees/{{.Branch | slug}}", "/src/app/.worktrees/feature-login"},
+	}
+	for _, tt := range tests {
+		got, err := Path(tt.tmpl, data)
+		if err != nil {
+			t.Errorf("Path(%q) failed: %v", tt.tmpl, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Path(%q) = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+
+	for _, tmpl := range []string{"{{.Unknown}}", "{{", "  ", "{{.RepoPath}}"} {
+		if _, err := Path(tmpl, data); err == nil {
+			t.Errorf("expected error for template %q", tmpl)
+		}
+	}
+}
+
+func TestUnique(t *testing.T) {
+	taken := map[string]bool{"/w/a": true, "/w/a-2": true}
+	if got := Unique("/w/a", func(p string) bool { return taken[p] }); got != "/w/a-3" {
+		t.Errorf("unexpected path: %s", got)
+	}
+	if got := Unique("/w/b", func(p string) bool { return taken[p] }); got != "/w/b" {
+		t.Errorf("unexpected path: %s", got)
+	}
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := OpenStore(dir)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	s.Set("/w/feature-a-b", "feature/a-b")
+	s.Set("/w/feature-a-b-2/", "feature-a/b")
+	s.SetDetached("/w/v1.0", "v1.0")
+	s.SetBase("/w/feature-a-b", "develop")
+	if err := s.Save(); err != nil {
+		t.Fatalf("failed to save store: %v", err)
+	}
+
+	s, err = OpenStore(dir)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	if branch, ok := s.Branch("/w/feature-a-b-2"); !ok || branch != "feature-a/b" {
+		t.Errorf("unexpected branch: %q, %v", branch, ok)
+	}
+	if name, ok := s.DetachedName("/w/v1.0"); !ok || name != "v1.0" {
+		t.Errorf("unexpected detached name: %q, %v", name, ok)
+	}
+	if _, ok := s.Branch("/w/v1.0"); ok {
+		t.Error("expected detached worktree not to have a branch")
+	}
+	if base, ok := s.Base("/w/feature-a-b"); !ok || base != "develop" {
+		t.Errorf("unexpected base: %q, %v", base, ok)
+	}
+
+	s.Remove("/w/feature-a-b")
+	if _, ok := s.Branch("/w/feature-a-b"); ok {
+		t.Error("expected mapping to be removed")
+	}
+	if _, ok := s.Base("/w/feature-a-b"); ok {
+		t.Error("expected base to be removed")
+	}
+
+	// 存在しないディレクトリの対応は削除される
+	s.Set(dir, "main")
+	s.Prune()
+	if len(s.Worktrees) != 1 || len(s.Detached) != 0 {
+		t.Errorf("unexpected mappings after prune: %v, %v", s.Worktrees, s.Detached)
+	}
+}
+
+func TestPlacementTemplates(t *testing.T) {
+	data := Data{
+		Repo:     "app",
+		RepoPath: "/src/app",
+		Branch:   "feature/login",
+		BaseDir:  "wtree",
+		Root:     "/home/me/worktrees",
+		Host:     "github.com",
+		Owner:    "acme",
+	}
+
+	tests := []struct {
+		placement string
+		want      string
+	}{
+		{"", "/src/wtree/feature-login"},
+		{PlacementSibling, "/src/wtree/feature-login"},
+		{PlacementPerRepo, "/src/wtree/app/feature-login"},
+		{PlacementInside, "/src/app/wtree/feature-login"},
+		{PlacementRoot, "/home/me/worktrees/github.com/acme/app/feature-login"},
+	}
+	for _, tt := range tests {
+		tmpl, err := Template(tt.placement, "")
+		if err != nil {
+			t.Errorf("Template(%q) failed: %v", tt.placement, err)
+			continue
+		}
+		got, err := Path(tmpl, data)
+		if err != nil || got != tt.want {
+			t.Errorf("placement %q = %q, %v, want %q", tt.placement, got, err, tt.want)
+		}
+	}
+
+	if tmpl, _ := Template(PlacementRoot, "/tmp/{{.Branch}}"); tmpl != "/tmp/{{.Branch}}" {
+		t.Errorf("expected dir_template to take precedence, got %q", tmpl)
+	}
+	if _, err := Template("nested", ""); err == nil {
+		t.Error("expected error for unknown placement")
+	}
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		host  string
+		owner string
+	}{
+		{"https://github.com/acme/app.git", "github.com", "acme"},
+		{"https://user@gitlab.example.com:8443/group/sub/app", "gitlab.example.com", "group/sub"},
+		{"ssh://git@github.com:22/acme/app.git", "github.com", "acme"},
+		{"git@github.com:acme/app.git", "github.com", "acme"},
+		{"github.com:acme/app", "github.com", "acme"},
+		{"../remote.git", LocalHost, ""},
+		{"/srv/git/app.git", LocalHost, ""},
+		{"file:///srv/git/app.git", LocalHost, ""},
+		{`C:\repos\app`, LocalHost, ""},
+	}
+	for _, tt := range tests {
+		host, owner := ParseRemoteURL(tt.url)
+		if host != tt.host || owner != tt.owner {
+			t.Errorf("ParseRemoteURL(%q) = %q, %q, want %q, %q", tt.url, host, owner, tt.host, tt.owner)
+		}
+	}
+}
+
+func TestEnsureExcluded(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "app")
+	common := filepath.Join(repo, ".git")
+
+	// リポジトリの外は何もしない
+	if err := EnsureExcluded(common, repo, filepath.Join(dir, "wtree", "a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(common, excludeFile)); !os.IsNotExist(err) {
+		t.Errorf("expected exclude file not to be created, got %v", err)
+	}
+
+	for _, branch := range []string{"a", "b"} {
+		if err := EnsureExcluded(common, repo, filepath.Join(repo, "wtree", branch)); err != nil {
+			t.Fatalf("failed to exclude: %v", err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(common, excludeFile))
+	if err != nil {
+		t.Fatalf("failed to read exclude file: %v", err)
+	}
+	if string(data) != "/wtree/\n" {
+		t.Errorf("unexpected exclude file: %q", data)
+	}
+}
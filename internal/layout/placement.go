@@ -0,0 +1,118 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+)
+
+// worktree の配置方法
+const (
+	// PlacementSibling はリポジトリと同じ階層の base_dir 以下に配置する
+	PlacementSibling = "sibling"
+	// PlacementPerRepo はリポジトリと同じ階層の base_dir 以下に、リポジトリ名のディレクトリを作って配置する
+	PlacementPerRepo = "per_repo"
+	// PlacementInside はリポジトリ内の base_dir 以下に配置する
+	PlacementInside = "inside"
+	// PlacementRoot は worktree.root 以下に、リモートのホスト名・所有者・リポジトリ名で分けて配置する
+	PlacementRoot = "root"
+)
+
+// placementTemplates は配置方法ごとのディレクトリのテンプレート
+var placementTemplates = map[string]string{
+	PlacementSibling: DefaultTemplate,
+	PlacementPerRepo: "{{.BaseDir}}/{{.Repo}}/{{.Branch | slug}}",
+	PlacementInside:  "{{.RepoPath}}/{{.BaseDir}}/{{.Branch | slug}}",
+	PlacementRoot:    "{{.Root}}/{{.Host}}/{{.Owner}}/{{.Repo}}/{{.Branch | slug}}",
+}
+
+// Template は worktree のパスのテンプレートを返す
+// dirTemplate が指定されている場合はそれを優先し、なければ配置方法のテンプレートを返す
+func Template(placement, dirTemplate string) (string, error) {
+	if dirTemplate != "" {
+		return dirTemplate, nil
+	}
+	if placement == "" {
+		placement = PlacementSibling
+	}
+	tmpl, ok := placementTemplates[placement]
+	if !ok {
+		return "", i18n.Errorf(i18n.MsgLayoutUnknownPlacement, placement)
+	}
+	return tmpl, nil
+}
+
+// LocalHost はリモートのURLにホスト名が含まれない場合（ローカルのパスなど）のホスト名
+const LocalHost = "local"
+
+// ParseRemoteURL はリモートのURLからホスト名と所有者を返す
+// https://host/owner/repo.git 、ssh://user@host:22/owner/repo.git 、user@host:owner/repo.git の形式に対応する
+// GitLab のサブグループのように階層がある場合、所有者は group/subgroup のようになる
+func ParseRemoteURL(url string) (host, owner string) {
+	url = strings.TrimSpace(url)
+
+	var path string
+	if scheme, rest, ok := strings.Cut(url, "://"); ok {
+		if scheme == "file" {
+			return LocalHost, ""
+		}
+		host, path, _ = strings.Cut(rest, "/")
+		host = host[strings.LastIndex(host, "@")+1:]
+		if i := strings.LastIndex(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+	} else {
+		// scp 形式。: より前に / を含む場合やドライブ名はローカルのパスとみなす
+		before, rest, ok := strings.Cut(url, ":")
+		if !ok || strings.ContainsAny(before, `/\`) || len(before) <= 1 {
+			return LocalHost, ""
+		}
+		host = before[strings.LastIndex(before, "@")+1:]
+		path = rest
+	}
+
+	if host == "" {
+		return LocalHost, ""
+	}
+	path = strings.Trim(path, "/")
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		owner = path[:i]
+	}
+	return host, owner
+}
+
+// excludeFile はリポジトリ内の worktree を無視するためのファイル（Gitの共通ディレクトリからの相対パス）
+const excludeFile = "info/exclude"
+
+// EnsureExcluded は path がメインworktreeの中にある場合、その最上位のディレクトリを .git/info/exclude に追加する
+// worktree のディレクトリが git status に未追跡のファイルとして表示されないようにする
+func EnsureExcluded(gitCommonDir, repoRoot, path string) error {
+	rel, err := filepath.Rel(repoRoot, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	top, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+	entry := "/" + top + "/"
+
+	excludePath := filepath.Join(gitCommonDir, excludeFile)
+	data, err := os.ReadFile(excludePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == entry {
+			return nil
+		}
+	}
+
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	data = append(data, entry+"\n"...)
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(excludePath, data, 0644)
+}
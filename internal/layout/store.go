@@ -0,0 +1,130 @@
+package layout
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// storeFile は worktree とブランチの対応表を保存するファイル
+// リポジトリと一緒に削除されるよう、Gitの共通ディレクトリ（.git）の下に保存する
+const storeFile = "scion/worktrees.json"
+
+// Store は scion が作成した worktree のパスとブランチの対応表
+// ディレクトリ名からブランチ名を復元できない場合（Slug の衝突や detached HEAD など）に使用する
+type Store struct {
+	path string
+	// Worktrees は worktree の絶対パスと、作成したブランチ名の対応
+	Worktrees map[string]string `json:"worktrees"`
+	// Detached はブランチを作成せずに（detached HEAD で）作成した worktree の絶対パスと名前の対応
+	Detached map[string]string `json:"detached,omitempty"`
+	// Bases は worktree の絶対パスと、ブランチを作成したベース（scion sync の取り込み元）の対応
+	Bases map[string]string `json:"bases,omitempty"`
+}
+
+// OpenStore は Gitの共通ディレクトリから対応表を読み込む
+// ファイルが存在しない場合は空の対応表を返す
+func OpenStore(gitCommonDir string) (*Store, error) {
+	s := &Store{
+		path:      filepath.Join(gitCommonDir, storeFile),
+		Worktrees: map[string]string{},
+		Detached:  map[string]string{},
+		Bases:     map[string]string{},
+	}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if s.Worktrees == nil {
+		s.Worktrees = map[string]string{}
+	}
+	if s.Detached == nil {
+		s.Detached = map[string]string{}
+	}
+	if s.Bases == nil {
+		s.Bases = map[string]string{}
+	}
+	return s, nil
+}
+
+// Save は対応表を保存する
+// 書き込み途中で中断しても壊れないよう、一時ファイルに書き込んでから置き換える
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Set は worktree のパスとブランチ名の対応を記録する
+func (s *Store) Set(path, branch string) {
+	s.Worktrees[filepath.Clean(path)] = branch
+}
+
+// SetDetached はブランチを持たない worktree のパスと名前の対応を記録する
+func (s *Store) SetDetached(path, name string) {
+	s.Detached[filepath.Clean(path)] = name
+}
+
+// SetBase は worktree のブランチを作成したベースを記録する
+func (s *Store) SetBase(path, base string) {
+	s.Bases[filepath.Clean(path)] = base
+}
+
+// Remove は worktree のパスの対応を削除する
+func (s *Store) Remove(path string) {
+	for _, m := range s.maps() {
+		delete(m, filepath.Clean(path))
+	}
+}
+
+// Prune は存在しなくなったディレクトリの対応を削除する
+// git worktree remove などで scion を使わずに削除された worktree の記録が残らないようにする
+func (s *Store) Prune() {
+	for _, m := range s.maps() {
+		for path := range m {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				delete(m, path)
+			}
+		}
+	}
+}
+
+// Branch は worktree のパスに対応するブランチ名を返す
+func (s *Store) Branch(path string) (string, bool) {
+	branch, ok := s.Worktrees[filepath.Clean(path)]
+	return branch, ok
+}
+
+// DetachedName はブランチを持たない worktree のパスに対応する名前を返す
+func (s *Store) DetachedName(path string) (string, bool) {
+	name, ok := s.Detached[filepath.Clean(path)]
+	return name, ok
+}
+
+// Base は worktree のブランチを作成したベースを返す
+func (s *Store) Base(path string) (string, bool) {
+	base, ok := s.Bases[filepath.Clean(path)]
+	return base, ok
+}
+
+// maps はパスをキーとする対応表の一覧を返す
+func (s *Store) maps() []map[string]string {
+	return []map[string]string{s.Worktrees, s.Detached, s.Bases}
+}
@@ -0,0 +1,93 @@
+package picker
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// スコアの重み
+const (
+	scoreMatch       = 16
+	scoreConsecutive = 8
+	scoreBoundary    = 12
+	scoreFirstChar   = 4
+	penaltyGap       = 1
+)
+
+// Match はクエリの文字が text にこの順序で含まれるかどうかを調べ、一致度のスコアを返す
+// 大文字と小文字は区別しない
+// 連続する一致や、区切り文字（/ - _ . 空白）の直後での一致ほどスコアが高くなる
+func Match(query, text string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	q := []rune(strings.ToLower(query))
+	t := []rune(text)
+
+	score := 0
+	qi := 0
+	last := -1
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if unicode.ToLower(t[ti]) != q[qi] {
+			continue
+		}
+
+		score += scoreMatch
+		switch {
+		case ti == 0:
+			score += scoreFirstChar + scoreBoundary
+		case isBoundary(t[ti-1]):
+			score += scoreBoundary
+		}
+		if last >= 0 {
+			if ti == last+1 {
+				score += scoreConsecutive
+			} else {
+				score -= penaltyGap * (ti - last - 1)
+			}
+		}
+		last = ti
+		qi++
+	}
+
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+// isBoundary は単語の区切りとみなす文字かどうかを返す
+func isBoundary(r rune) bool {
+	switch r {
+	case '/', '-', '_', '.', ' ':
+		return true
+	}
+	return false
+}
+
+// Filter はクエリに一致する項目のインデックスをスコアの高い順に返す
+// スコアが同じ場合は元の順序を保つ
+func Filter(query string, items []Item) []int {
+	type scored struct {
+		index int
+		score int
+	}
+
+	var matches []scored
+	for i, item := range items {
+		if score, ok := Match(query, item.Label); ok {
+			matches = append(matches, scored{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].score > matches[b].score
+	})
+
+	indexes := make([]int, len(matches))
+	for i, m := range matches {
+		indexes[i] = m.index
+	}
+	return indexes
+}
@@ -0,0 +1,330 @@
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/keys"
+	"github.com/ongasatoshi/scion/pkg/output"
+	"golang.org/x/term"
+)
+
+// defaultHeight は一覧に表示する最大行数のデフォルト値
+const defaultHeight = 10
+
+// previewHeight はプレビューに表示する最大行数
+const previewHeight = 8
+
+// ErrCancelled はユーザーが選択をキャンセルした場合に返される
+var ErrCancelled = errors.New("picker: cancelled")
+
+// ErrNotTerminal は標準入力または標準エラー出力が端末でない場合に返される
+var ErrNotTerminal = errors.New("picker: not a terminal")
+
+// Item は選択肢
+type Item struct {
+	// Label は一覧に表示され、絞り込みの対象となる文字列
+	Label string
+	// Detail はラベルの右側に表示される補足情報
+	Detail string
+	// Value は呼び出し元が項目を識別するための値
+	Value string
+}
+
+// Options はピッカーの動作を指定する
+type Options struct {
+	// Prompt は入力欄の前に表示する文字列
+	Prompt string
+	// Multi は複数選択を許可するかどうか
+	Multi bool
+	// Preview は選択中の項目のプレビューを返す（nil の場合はプレビューを表示しない）
+	Preview func(Item) string
+	// Height は一覧に表示する最大行数（0 の場合は10行）
+	Height int
+}
+
+// Available はピッカーを表示できる端末かどうかを返す
+// 一覧は標準エラー出力に描画するため、標準出力がパイプでも使用できる
+func Available() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// Run はピッカーを表示し、選択された項目を返す
+// 単一選択の場合は1件、複数選択の場合は Tab で選んだ項目（選んでいなければカーソル位置の項目）を返す
+func Run(items []Item, opts Options) ([]Item, error) {
+	if !Available() {
+		return nil, ErrNotTerminal
+	}
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	defer term.Restore(fd, state)
+
+	width, _, err := term.GetSize(int(os.Stderr.Fd()))
+	if err != nil || width <= 0 {
+		width = 80
+	}
+	// 行末ちょうどまで描画すると端末によっては折り返されるため、1桁空けておく
+	width--
+
+	m := newModel(items, opts)
+	r := &renderer{w: os.Stderr}
+	io.WriteString(r.w, "\033[?25l")
+	defer func() {
+		r.clear()
+		io.WriteString(r.w, "\033[?25h")
+	}()
+
+	buf := make([]byte, 64)
+	for {
+		r.draw(m.view(width))
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		for _, ev := range keys.Parse(buf[:n]) {
+			switch m.handle(ev) {
+			case actionAccept:
+				return m.selected(), nil
+			case actionCancel:
+				return nil, ErrCancelled
+			}
+		}
+	}
+}
+
+// action はキー入力を処理した結果
+type action int
+
+const (
+	actionNone action = iota
+	actionAccept
+	actionCancel
+)
+
+// model はピッカーの状態
+type model struct {
+	items   []Item
+	opts    Options
+	query   []rune
+	matches []int
+	cursor  int
+	offset  int
+	chosen  map[int]bool
+	preview map[int]string
+}
+
+func newModel(items []Item, opts Options) *model {
+	if opts.Height <= 0 {
+		opts.Height = defaultHeight
+	}
+	m := &model{
+		items:   items,
+		opts:    opts,
+		chosen:  map[int]bool{},
+		preview: map[int]string{},
+	}
+	m.refilter()
+	return m
+}
+
+// refilter はクエリで項目を絞り込み、カーソルを先頭に戻す
+func (m *model) refilter() {
+	m.matches = Filter(string(m.query), m.items)
+	m.cursor = 0
+	m.offset = 0
+}
+
+// handle はキー入力を処理する
+func (m *model) handle(ev keys.Event) action {
+	switch ev.Kind {
+	case keys.Rune:
+		m.query = append(m.query, ev.Rune)
+		m.refilter()
+	case keys.Backspace:
+		if len(m.query) > 0 {
+			m.query = m.query[:len(m.query)-1]
+			m.refilter()
+		}
+	case keys.Clear:
+		m.query = nil
+		m.refilter()
+	case keys.Up:
+		m.move(-1)
+	case keys.Down:
+		m.move(1)
+	case keys.Tab:
+		if m.opts.Multi && len(m.matches) > 0 {
+			index := m.matches[m.cursor]
+			m.chosen[index] = !m.chosen[index]
+			m.move(1)
+		}
+	case keys.Enter:
+		if len(m.matches) > 0 || len(m.chosenItems()) > 0 {
+			return actionAccept
+		}
+	case keys.Escape, keys.Interrupt:
+		return actionCancel
+	}
+	return actionNone
+}
+
+// move はカーソルを移動し、カーソルが表示範囲に収まるようスクロールする
+func (m *model) move(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor += delta
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	if m.cursor >= len(m.matches) {
+		m.cursor = len(m.matches) - 1
+	}
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.opts.Height {
+		m.offset = m.cursor - m.opts.Height + 1
+	}
+}
+
+// chosenItems は Tab で選んだ項目を元の順序で返す
+func (m *model) chosenItems() []Item {
+	var items []Item
+	for i, item := range m.items {
+		if m.chosen[i] {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// selected は確定した項目を返す
+func (m *model) selected() []Item {
+	if chosen := m.chosenItems(); len(chosen) > 0 {
+		return chosen
+	}
+	if len(m.matches) == 0 {
+		return nil
+	}
+	return []Item{m.items[m.matches[m.cursor]]}
+}
+
+// view は描画する行を返す
+func (m *model) view(width int) []string {
+	var lines []string
+
+	lines = append(lines, output.Truncate(m.opts.Prompt+"> "+string(m.query), width))
+
+	hint := i18n.Text(i18n.MsgPickerHint)
+	if m.opts.Multi {
+		hint = i18n.Text(i18n.MsgPickerHintMulti)
+	}
+	lines = append(lines, output.Truncate(fmt.Sprintf("  %d/%d  %s", len(m.matches), len(m.items), hint), width))
+
+	labelWidth := 0
+	for _, item := range m.items {
+		if w := output.DisplayWidth(item.Label); w > labelWidth {
+			labelWidth = w
+		}
+	}
+
+	end := m.offset + m.opts.Height
+	if end > len(m.matches) {
+		end = len(m.matches)
+	}
+	for i := m.offset; i < end; i++ {
+		index := m.matches[i]
+		item := m.items[index]
+
+		var b strings.Builder
+		if i == m.cursor {
+			b.WriteString("❯ ")
+		} else {
+			b.WriteString("  ")
+		}
+		if m.opts.Multi {
+			if m.chosen[index] {
+				b.WriteString("● ")
+			} else {
+				b.WriteString("○ ")
+			}
+		}
+		b.WriteString(item.Label)
+		if item.Detail != "" {
+			b.WriteString(strings.Repeat(" ", labelWidth-output.DisplayWidth(item.Label)+2))
+			b.WriteString(item.Detail)
+		}
+
+		line := output.Truncate(b.String(), width)
+		if i == m.cursor {
+			line = "\033[1m" + line + "\033[0m"
+		}
+		lines = append(lines, line)
+	}
+
+	if m.opts.Preview != nil && len(m.matches) > 0 {
+		lines = append(lines, strings.Repeat("─", width))
+		for _, line := range m.previewLines(m.matches[m.cursor]) {
+			lines = append(lines, output.Truncate(line, width))
+		}
+	}
+	return lines
+}
+
+// previewLines は項目のプレビューを返す
+// プレビューの生成にはgitコマンドの実行などを伴うため、項目ごとに結果を保持する
+func (m *model) previewLines(index int) []string {
+	text, ok := m.preview[index]
+	if !ok {
+		text = m.opts.Preview(m.items[index])
+		m.preview[index] = text
+	}
+
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) > previewHeight {
+		lines = lines[:previewHeight]
+	}
+	return lines
+}
+
+// renderer はピッカーを端末の現在位置に描画する
+type renderer struct {
+	w     io.Writer
+	lines int
+}
+
+// draw は前回描画した領域を消去して描画し直す
+func (r *renderer) draw(lines []string) {
+	var b strings.Builder
+	r.rewind(&b)
+	b.WriteString(strings.Join(lines, "\r\n"))
+	r.lines = len(lines)
+	io.WriteString(r.w, b.String())
+}
+
+// clear は描画した領域を消去する
+func (r *renderer) clear() {
+	var b strings.Builder
+	r.rewind(&b)
+	r.lines = 0
+	io.WriteString(r.w, b.String())
+}
+
+// rewind はカーソルを描画領域の先頭に戻し、以降を消去するシーケンスを書き込む
+func (r *renderer) rewind(b *strings.Builder) {
+	if r.lines > 1 {
+		fmt.Fprintf(b, "\033[%dA", r.lines-1)
+	}
+	b.WriteString("\r\033[J")
+}
@@ -0,0 +1,124 @@
+package picker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ongasatoshi/scion/internal/keys"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		query  string
+		text   string
+		wantOK bool
+	}{
+		{"", "anything", true},
+		{"flog", "feature/login", true},
+		{"FLOG", "feature/login", true},
+		{"login", "feature/login", true},
+		{"xyz", "feature/login", false},
+		{"gol", "feature/login", false},
+	}
+
+	for _, tt := range tests {
+		if _, ok := Match(tt.query, tt.text); ok != tt.wantOK {
+			t.Errorf("Match(%q, %q) ok = %v, want %v", tt.query, tt.text, ok, tt.wantOK)
+		}
+	}
+}
+
+func TestMatchPrefersConsecutiveAndBoundary(t *testing.T) {
+	consecutive, _ := Match("log", "feature/login")
+	scattered, _ := Match("log", "fix-large-options-gap")
+	if consecutive <= scattered {
+		t.Errorf("expected consecutive match to score higher (%d <= %d)", consecutive, scattered)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	items := []Item{
+		{Label: "main"},
+		{Label: "feature/login"},
+		{Label: "bugfix/logout"},
+		{Label: "feature/payment"},
+	}
+
+	got := Filter("log", items)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 matches, got %v", got)
+	}
+	for _, index := range got {
+		if !strings.Contains(items[index].Label, "log") {
+			t.Errorf("unexpected match: %s", items[index].Label)
+		}
+	}
+
+	if all := Filter("", items); len(all) != len(items) {
+		t.Errorf("expected empty query to match all items, got %v", all)
+	}
+}
+
+func TestModelSingleSelect(t *testing.T) {
+	items := []Item{{Label: "main"}, {Label: "feature/login"}, {Label: "feature/payment"}}
+	m := newModel(items, Options{})
+
+	for _, r := range "pay" {
+		m.handle(keys.Event{Kind: keys.Rune, Rune: r})
+	}
+	if m.handle(keys.Event{Kind: keys.Enter}) != actionAccept {
+		t.Fatal("expected enter to accept")
+	}
+
+	selected := m.selected()
+	if len(selected) != 1 || selected[0].Label != "feature/payment" {
+		t.Errorf("unexpected selection: %v", selected)
+	}
+}
+
+func TestModelMultiSelect(t *testing.T) {
+	items := []Item{{Label: "a"}, {Label: "b"}, {Label: "c"}}
+	m := newModel(items, Options{Multi: true})
+
+	// a を選択してカーソルは b へ、b をスキップして c を選択
+	m.handle(keys.Event{Kind: keys.Tab})
+	m.handle(keys.Event{Kind: keys.Down})
+	m.handle(keys.Event{Kind: keys.Tab})
+
+	selected := m.selected()
+	if len(selected) != 2 || selected[0].Label != "a" || selected[1].Label != "c" {
+		t.Errorf("unexpected selection: %v", selected)
+	}
+}
+
+func TestModelNoMatch(t *testing.T) {
+	m := newModel([]Item{{Label: "main"}}, Options{})
+	m.handle(keys.Event{Kind: keys.Rune, Rune: 'z'})
+
+	if m.handle(keys.Event{Kind: keys.Enter}) != actionNone {
+		t.Error("expected enter to be ignored without matches")
+	}
+	if m.handle(keys.Event{Kind: keys.Escape}) != actionCancel {
+		t.Error("expected cancel")
+	}
+}
+
+func TestModelScroll(t *testing.T) {
+	var items []Item
+	for _, label := range []string{"a", "b", "c", "d", "e"} {
+		items = append(items, Item{Label: label})
+	}
+	m := newModel(items, Options{Height: 2})
+
+	for i := 0; i < 3; i++ {
+		m.handle(keys.Event{Kind: keys.Down})
+	}
+	if m.cursor != 3 || m.offset != 2 {
+		t.Errorf("cursor = %d, offset = %d, want 3, 2", m.cursor, m.offset)
+	}
+
+	// 見出し2行 + 一覧2行
+	if lines := m.view(80); len(lines) != 4 {
+		t.Errorf("expected 4 lines, got %d", len(lines))
+	}
+}
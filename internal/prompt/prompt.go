@@ -0,0 +1,191 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// AssumeYesEnv はすべての確認に自動で同意する環境変数名
+const AssumeYesEnv = "SCION_ASSUME_YES"
+
+// ErrNotInteractive は入力が端末でないため確認できない場合に返される
+var ErrNotInteractive = errors.New("prompt: not interactive")
+
+// ErrTimeout は応答が制限時間内に入力されなかった場合に返される
+var ErrTimeout = errors.New("prompt: timed out")
+
+// Prompter はユーザーに確認や入力を求める
+type Prompter struct {
+	// In は応答を読み込む入力
+	In io.Reader
+	// Out は質問を書き込む出力
+	Out io.Writer
+	// Interactive は入力が端末かどうか（false の場合は ErrNotInteractive を返す）
+	Interactive bool
+	// AssumeYes は質問せずにデフォルトの応答（Confirm では同意）を使用するかどうか
+	AssumeYes bool
+	// Timeout は応答を待つ時間（0 の場合は無制限）
+	Timeout time.Duration
+
+	reader *bufio.Reader
+}
+
+// New は標準入力から応答を読み込み、out に質問を書き込む Prompter を作成する
+// 環境変数 SCION_ASSUME_YES が真の場合は AssumeYes が有効になる
+func New(out io.Writer) *Prompter {
+	return &Prompter{
+		In:          os.Stdin,
+		Out:         out,
+		Interactive: term.IsTerminal(int(os.Stdin.Fd())),
+		AssumeYes:   AssumeYesFromEnv(),
+	}
+}
+
+// AssumeYesFromEnv は環境変数 SCION_ASSUME_YES が真かどうかを返す
+func AssumeYesFromEnv() bool {
+	yes, err := strconv.ParseBool(os.Getenv(AssumeYesEnv))
+	return err == nil && yes
+}
+
+// Confirm は yes/no の確認を求める
+// 空の応答は defaultYes として扱い、y/yes/n/no 以外の応答には再度確認する
+// 端末で入力を終了した場合 (Ctrl-D) は同意しなかったものとして扱う
+func (p *Prompter) Confirm(message string, defaultYes bool) (bool, error) {
+	if p.AssumeYes {
+		return true, nil
+	}
+	if !p.Interactive {
+		return false, ErrNotInteractive
+	}
+
+	hint := "[y/N]"
+	if defaultYes {
+		hint = "[Y/n]"
+	}
+	for {
+		fmt.Fprintf(p.Out, "%s %s: ", message, hint)
+		answer, err := p.readLine()
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(p.Out)
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return defaultYes, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// Select は選択肢の番号の入力を求め、選ばれた選択肢のインデックスを返す
+// 空の応答は defaultIndex として扱い、範囲外の番号には再度入力を求める
+func (p *Prompter) Select(message string, options []string, defaultIndex int) (int, error) {
+	if p.AssumeYes {
+		return defaultIndex, nil
+	}
+	if !p.Interactive {
+		return 0, ErrNotInteractive
+	}
+
+	fmt.Fprintln(p.Out, message)
+	for i, option := range options {
+		fmt.Fprintf(p.Out, "  %d) %s\n", i+1, option)
+	}
+	for {
+		fmt.Fprintf(p.Out, "[%d]: ", defaultIndex+1)
+		answer, err := p.readLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				fmt.Fprintln(p.Out)
+			}
+			return 0, err
+		}
+
+		if answer == "" {
+			return defaultIndex, nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+	}
+}
+
+// Input は文字列の入力を求める
+// 空の応答は defaultValue として扱う
+func (p *Prompter) Input(message, defaultValue string) (string, error) {
+	if p.AssumeYes {
+		return defaultValue, nil
+	}
+	if !p.Interactive {
+		return "", ErrNotInteractive
+	}
+
+	if defaultValue != "" {
+		fmt.Fprintf(p.Out, "%s [%s]: ", message, defaultValue)
+	} else {
+		fmt.Fprintf(p.Out, "%s: ", message)
+	}
+	answer, err := p.readLine()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(p.Out)
+		}
+		return "", err
+	}
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+// readLine は1行を読み込み、前後の空白を除いて返す
+// Timeout が設定されている場合は、時間内に入力されなければ ErrTimeout を返す
+func (p *Prompter) readLine() (string, error) {
+	if p.reader == nil {
+		p.reader = bufio.NewReader(p.In)
+	}
+
+	type result struct {
+		line string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		line, err := p.reader.ReadString('\n')
+		// 改行のない最終行は応答として扱う
+		if errors.Is(err, io.EOF) && line != "" {
+			err = nil
+		}
+		done <- result{strings.TrimSpace(line), err}
+	}()
+
+	if p.Timeout <= 0 {
+		r := <-done
+		return r.line, r.err
+	}
+
+	timer := time.NewTimer(p.Timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.line, r.err
+	case <-timer.C:
+		fmt.Fprintln(p.Out)
+		return "", ErrTimeout
+	}
+}
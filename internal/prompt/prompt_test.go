@@ -0,0 +1,140 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestPrompter は文字列から応答を読み込む Prompter を作成する
+func newTestPrompter(input string) (*Prompter, *bytes.Buffer) {
+	var out bytes.Buffer
+	return &Prompter{In: strings.NewReader(input), Out: &out, Interactive: true}, &out
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input      string
+		defaultYes bool
+		want       bool
+	}{
+		{"y\n", false, true},
+		{"YES\n", false, true},
+		{"n\n", true, false},
+		{"\n", false, false},
+		{"\n", true, true},
+		// 不正な応答には再度確認する
+		{"maybe\ny\n", false, true},
+		// 入力の終了は同意しなかったものとして扱う
+		{"", true, false},
+	}
+
+	for _, tt := range tests {
+		p, _ := newTestPrompter(tt.input)
+		got, err := p.Confirm("Continue?", tt.defaultYes)
+		if err != nil {
+			t.Fatalf("Confirm(%q) failed: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("Confirm(%q, %v) = %v, want %v", tt.input, tt.defaultYes, got, tt.want)
+		}
+	}
+}
+
+func TestConfirmPrintsHint(t *testing.T) {
+	p, out := newTestPrompter("y\n")
+	if _, err := p.Confirm("Remove?", false); err != nil {
+		t.Fatalf("Confirm failed: %v", err)
+	}
+	if out.String() != "Remove? [y/N]: " {
+		t.Errorf("unexpected prompt: %q", out.String())
+	}
+}
+
+func TestNotInteractive(t *testing.T) {
+	p, out := newTestPrompter("y\n")
+	p.Interactive = false
+
+	if _, err := p.Confirm("Remove?", false); !errors.Is(err, ErrNotInteractive) {
+		t.Errorf("expected ErrNotInteractive from Confirm, got %v", err)
+	}
+	if _, err := p.Select("Pick", []string{"a"}, 0); !errors.Is(err, ErrNotInteractive) {
+		t.Errorf("expected ErrNotInteractive from Select, got %v", err)
+	}
+	if _, err := p.Input("Name", ""); !errors.Is(err, ErrNotInteractive) {
+		t.Errorf("expected ErrNotInteractive from Input, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected nothing to be printed, got %q", out.String())
+	}
+}
+
+func TestAssumeYes(t *testing.T) {
+	p, out := newTestPrompter("")
+	p.Interactive = false
+	p.AssumeYes = true
+
+	if ok, err := p.Confirm("Remove?", false); err != nil || !ok {
+		t.Errorf("expected Confirm to agree, got %v, %v", ok, err)
+	}
+	if index, err := p.Select("Pick", []string{"a", "b"}, 1); err != nil || index != 1 {
+		t.Errorf("expected default index, got %d, %v", index, err)
+	}
+	if value, err := p.Input("Name", "main"); err != nil || value != "main" {
+		t.Errorf("expected default value, got %q, %v", value, err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected nothing to be printed, got %q", out.String())
+	}
+}
+
+func TestAssumeYesFromEnv(t *testing.T) {
+	for value, want := range map[string]bool{"1": true, "true": true, "0": false, "no": false, "": false} {
+		t.Setenv(AssumeYesEnv, value)
+		if got := AssumeYesFromEnv(); got != want {
+			t.Errorf("%s=%q: got %v, want %v", AssumeYesEnv, value, got, want)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	p, out := newTestPrompter("5\n2\n")
+	index, err := p.Select("Pick a branch", []string{"main", "develop", "release"}, 0)
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if index != 1 {
+		t.Errorf("expected index 1, got %d", index)
+	}
+	if !strings.Contains(out.String(), "  2) develop\n") {
+		t.Errorf("expected options to be listed, got %q", out.String())
+	}
+
+	p, _ = newTestPrompter("\n")
+	if index, _ := p.Select("Pick", []string{"a", "b"}, 1); index != 1 {
+		t.Errorf("expected default index on empty answer, got %d", index)
+	}
+}
+
+func TestInput(t *testing.T) {
+	p, _ := newTestPrompter("feature/x\n\n")
+	if value, _ := p.Input("Branch", "main"); value != "feature/x" {
+		t.Errorf("expected entered value, got %q", value)
+	}
+	if value, _ := p.Input("Branch", "main"); value != "main" {
+		t.Errorf("expected default value, got %q", value)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	reader, writer := io.Pipe()
+	defer writer.Close()
+
+	p := &Prompter{In: reader, Out: io.Discard, Interactive: true, Timeout: 20 * time.Millisecond}
+	if _, err := p.Confirm("Remove?", false); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+}
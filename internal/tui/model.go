@@ -0,0 +1,390 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ongasatoshi/scion/internal/agent"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/keys"
+	"github.com/ongasatoshi/scion/pkg/output"
+)
+
+// Row はダッシュボードに表示する worktree の状態
+type Row struct {
+	Worktree git.WorktreeInfo
+	// Main はメインworktreeかどうか
+	Main bool
+	// Changes は未コミットの変更があるファイル数（取得できなかった場合は -1）
+	Changes int
+	// Ahead と Behind は上流ブランチとの差分のコミット数（Upstream が false の場合は無効）
+	Ahead    int
+	Behind   int
+	Upstream bool
+	// Agents は worktree 内で実行中のエージェント
+	Agents []agent.Process
+	// AgentsUnsupported はこの環境ではエージェントを検出できないかどうか
+	AgentsUnsupported bool
+}
+
+// label は一覧に表示するブランチ名を返す
+func (r Row) label() string {
+	label := r.Worktree.Branch
+	if r.Worktree.Detached {
+		label = i18n.Text(i18n.MsgListDetached)
+	}
+	if r.Worktree.Locked {
+		label += " " + i18n.Text(i18n.MsgUILockedMark)
+	}
+	return label
+}
+
+// mode はダッシュボードの表示状態
+type mode int
+
+const (
+	modeList mode = iota
+	modeInput
+	modeConfirm
+	modeDiff
+)
+
+// requestKind はキー入力によって要求された操作
+type requestKind int
+
+const (
+	requestNone requestKind = iota
+	requestQuit
+	requestRefresh
+	requestCreate
+	requestClear
+	requestAgent
+	requestEditor
+	requestDiff
+	requestLock
+)
+
+// request はキー入力によって要求された操作と対象
+type request struct {
+	kind   requestKind
+	row    Row
+	branch string
+}
+
+// model はダッシュボードの状態
+type model struct {
+	title   string
+	rows    []Row
+	cursor  int
+	mode    mode
+	input   []rune
+	message string
+
+	diff       []string
+	diffOffset int
+	height     int
+}
+
+// setRows は一覧を更新し、可能であれば同じ worktree にカーソルを保つ
+func (m *model) setRows(rows []Row) {
+	current := ""
+	if m.cursor < len(m.rows) {
+		current = m.rows[m.cursor].Worktree.Path
+	}
+
+	m.rows = rows
+	m.cursor = 0
+	for i, row := range rows {
+		if row.Worktree.Path == current {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// selected はカーソル位置の行を返す
+func (m *model) selected() (Row, bool) {
+	if m.cursor >= len(m.rows) {
+		return Row{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+// setDiff は差分表示に切り替える
+func (m *model) setDiff(diff string) {
+	if strings.TrimSpace(diff) == "" {
+		m.message = i18n.Text(i18n.MsgUINoDiff)
+		return
+	}
+	m.diff = strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	m.diffOffset = 0
+	m.mode = modeDiff
+}
+
+// handle はキー入力を処理し、要求された操作を返す
+func (m *model) handle(ev keys.Event) request {
+	switch m.mode {
+	case modeInput:
+		return m.handleInput(ev)
+	case modeConfirm:
+		return m.handleConfirm(ev)
+	case modeDiff:
+		m.handleDiff(ev)
+		return request{}
+	}
+
+	if ev.Kind == keys.Interrupt || ev.Kind == keys.Escape {
+		return request{kind: requestQuit}
+	}
+	switch {
+	case ev.Kind == keys.Up || ev.Rune == 'k':
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return request{}
+	case ev.Kind == keys.Down || ev.Rune == 'j':
+		if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+		return request{}
+	}
+	if ev.Kind != keys.Rune {
+		return request{}
+	}
+
+	m.message = ""
+	switch ev.Rune {
+	case 'q':
+		return request{kind: requestQuit}
+	case 'r':
+		return request{kind: requestRefresh}
+	case 'c':
+		m.mode = modeInput
+		m.input = nil
+		return request{}
+	}
+
+	row, ok := m.selected()
+	if !ok {
+		return request{}
+	}
+	switch ev.Rune {
+	case 'd':
+		if row.Main {
+			m.message = i18n.Text(i18n.MsgUIMainWorktree)
+			return request{}
+		}
+		m.mode = modeConfirm
+	case 'a':
+		return request{kind: requestAgent, row: row}
+	case 'o':
+		return request{kind: requestEditor, row: row}
+	case 'v':
+		return request{kind: requestDiff, row: row}
+	case 'l':
+		if row.Main {
+			m.message = i18n.Text(i18n.MsgUIMainWorktree)
+			return request{}
+		}
+		return request{kind: requestLock, row: row}
+	}
+	return request{}
+}
+
+// handleInput は新しいブランチ名の入力中のキー入力を処理する
+func (m *model) handleInput(ev keys.Event) request {
+	switch ev.Kind {
+	case keys.Rune:
+		m.input = append(m.input, ev.Rune)
+	case keys.Backspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case keys.Clear:
+		m.input = nil
+	case keys.Escape, keys.Interrupt:
+		m.mode = modeList
+	case keys.Enter:
+		m.mode = modeList
+		branch := strings.TrimSpace(string(m.input))
+		if branch != "" {
+			return request{kind: requestCreate, branch: branch}
+		}
+	}
+	return request{}
+}
+
+// handleConfirm は削除の確認中のキー入力を処理する
+func (m *model) handleConfirm(ev keys.Event) request {
+	m.mode = modeList
+	row, ok := m.selected()
+	if ok && ev.Kind == keys.Rune && (ev.Rune == 'y' || ev.Rune == 'Y') {
+		return request{kind: requestClear, row: row}
+	}
+	m.message = i18n.Text(i18n.MsgCancelled)
+	return request{}
+}
+
+// handleDiff は差分の表示中のキー入力を処理する
+func (m *model) handleDiff(ev keys.Event) {
+	page := m.height - 2
+	if page < 1 {
+		page = 1
+	}
+
+	switch {
+	case ev.Kind == keys.Escape || ev.Kind == keys.Interrupt || ev.Rune == 'q':
+		m.mode = modeList
+		m.diff = nil
+		return
+	case ev.Kind == keys.Up || ev.Rune == 'k':
+		m.diffOffset--
+	case ev.Kind == keys.Down || ev.Rune == 'j' || ev.Kind == keys.Enter:
+		m.diffOffset++
+	case ev.Kind == keys.PageUp || ev.Rune == 'b':
+		m.diffOffset -= page
+	case ev.Kind == keys.PageDown || ev.Rune == ' ':
+		m.diffOffset += page
+	case ev.Rune == 'g':
+		m.diffOffset = 0
+	case ev.Rune == 'G':
+		m.diffOffset = len(m.diff)
+	}
+
+	if m.diffOffset > len(m.diff)-page {
+		m.diffOffset = len(m.diff) - page
+	}
+	if m.diffOffset < 0 {
+		m.diffOffset = 0
+	}
+}
+
+// view は画面に描画する行を返す
+func (m *model) view(width, height int) []string {
+	m.height = height
+	if m.mode == modeDiff {
+		return m.viewDiff(width, height)
+	}
+
+	lines := []string{
+		"\033[1m" + output.Truncate(m.title, width) + "\033[0m",
+		"",
+	}
+
+	table := output.NewTable(
+		i18n.Text(i18n.MsgListHeaderBranch),
+		i18n.Text(i18n.MsgUIHeaderChanges),
+		i18n.Text(i18n.MsgUIHeaderSync),
+		i18n.Text(i18n.MsgUIHeaderAgents),
+		i18n.Text(i18n.MsgListHeaderPath),
+	)
+	table.SetTruncatable(4)
+	table.MaxWidth = width - 2
+	for _, row := range m.rows {
+		table.AddRow(row.label(), formatChanges(row), formatSync(row), formatAgents(row), row.Worktree.Path)
+	}
+	var buf bytes.Buffer
+	table.Render(&buf)
+
+	for i, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		// 先頭行は見出し
+		switch {
+		case i == 0:
+			lines = append(lines, "  "+line)
+		case i-1 == m.cursor:
+			lines = append(lines, "\033[7m❯ "+line+"\033[0m")
+		default:
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	// 下部にメッセージと操作方法を表示する
+	var footer []string
+	switch m.mode {
+	case modeInput:
+		footer = append(footer, i18n.Text(i18n.MsgUICreatePrompt)+string(m.input)+"█")
+	case modeConfirm:
+		row, _ := m.selected()
+		footer = append(footer, i18n.T(i18n.MsgUIClearConfirm, row.Worktree.Branch))
+	default:
+		// gitのエラーメッセージは複数行になることがあるため、先頭行だけを表示する
+		message, _, _ := strings.Cut(strings.TrimSpace(m.message), "\n")
+		footer = append(footer, output.Truncate(message, width))
+	}
+	footer = append(footer, "\033[2m"+output.Truncate(i18n.Text(i18n.MsgUIHint), width)+"\033[0m")
+
+	for len(lines)+len(footer) < height {
+		lines = append(lines, "")
+	}
+	return append(lines, footer...)
+}
+
+// viewDiff は差分の表示を返す
+func (m *model) viewDiff(width, height int) []string {
+	row, _ := m.selected()
+	lines := []string{"\033[1m" + output.Truncate(row.label(), width) + "\033[0m"}
+
+	end := m.diffOffset + height - 2
+	if end > len(m.diff) {
+		end = len(m.diff)
+	}
+	for _, line := range m.diff[m.diffOffset:end] {
+		line = output.Truncate(strings.ReplaceAll(line, "\t", "    "), width)
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			line = "\033[1m" + line + "\033[0m"
+		case strings.HasPrefix(line, "+"):
+			line = "\033[32m" + line + "\033[0m"
+		case strings.HasPrefix(line, "-"):
+			line = "\033[31m" + line + "\033[0m"
+		case strings.HasPrefix(line, "@@"):
+			line = "\033[36m" + line + "\033[0m"
+		}
+		lines = append(lines, line)
+	}
+
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	hint := fmt.Sprintf("%d-%d/%d  %s", m.diffOffset+1, end, len(m.diff), i18n.Text(i18n.MsgUIDiffHint))
+	return append(lines, "\033[2m"+output.Truncate(hint, width)+"\033[0m")
+}
+
+// formatChanges は未コミットの変更の列を返す
+func formatChanges(row Row) string {
+	switch {
+	case row.Changes < 0:
+		return "?"
+	case row.Changes == 0:
+		return "-"
+	}
+	return fmt.Sprintf("%d", row.Changes)
+}
+
+// formatSync は上流ブランチとの差分の列を返す
+func formatSync(row Row) string {
+	if !row.Upstream {
+		return "-"
+	}
+	if row.Ahead == 0 && row.Behind == 0 {
+		return "="
+	}
+	return fmt.Sprintf("↑%d ↓%d", row.Ahead, row.Behind)
+}
+
+// formatAgents は実行中のエージェントの列を返す
+func formatAgents(row Row) string {
+	if row.AgentsUnsupported {
+		return i18n.Text(i18n.MsgUIAgentsUnsupported)
+	}
+	if len(row.Agents) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(row.Agents))
+	for _, p := range row.Agents {
+		names = append(names, p.Name)
+	}
+	return strings.Join(names, ",")
+}
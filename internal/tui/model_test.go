@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ongasatoshi/scion/internal/agent"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/keys"
+)
+
+func testModel() *model {
+	m := &model{title: "scion"}
+	m.setRows([]Row{
+		{Worktree: git.WorktreeInfo{Branch: "main", Path: "/repo/app"}, Main: true},
+		{Worktree: git.WorktreeInfo{Branch: "feature/a", Path: "/repo/wtree/feature-a"}, Changes: 3},
+		{
+			Worktree: git.WorktreeInfo{Branch: "feature/b", Path: "/repo/wtree/feature-b", Locked: true},
+			Upstream: true, Ahead: 2, Behind: 1,
+			Agents: []agent.Process{{PID: 42, Name: "claude"}},
+		},
+	})
+	return m
+}
+
+func runeEvent(r rune) keys.Event {
+	return keys.Event{Kind: keys.Rune, Rune: r}
+}
+
+func TestMoveAndKeepCursorOnRefresh(t *testing.T) {
+	m := testModel()
+
+	m.handle(runeEvent('j'))
+	m.handle(keys.Event{Kind: keys.Down})
+	m.handle(keys.Event{Kind: keys.Down})
+	if m.cursor != 2 {
+		t.Fatalf("expected cursor 2, got %d", m.cursor)
+	}
+
+	// 行の順序が変わってもカーソルは同じ worktree を指す
+	m.setRows([]Row{m.rows[2], m.rows[0], m.rows[1]})
+	if m.cursor != 0 {
+		t.Errorf("expected cursor to follow the worktree, got %d", m.cursor)
+	}
+}
+
+func TestCreateInput(t *testing.T) {
+	m := testModel()
+
+	m.handle(runeEvent('c'))
+	for _, r := range "fix/x" {
+		m.handle(runeEvent(r))
+	}
+	m.handle(keys.Event{Kind: keys.Backspace})
+	m.handle(runeEvent('y'))
+
+	req := m.handle(keys.Event{Kind: keys.Enter})
+	if req.kind != requestCreate || req.branch != "fix/y" {
+		t.Errorf("unexpected request: %+v", req)
+	}
+	if m.mode != modeList {
+		t.Errorf("expected to return to the list, got mode %d", m.mode)
+	}
+}
+
+func TestClearRequiresConfirmation(t *testing.T) {
+	m := testModel()
+
+	// メインworktreeは削除できない
+	if req := m.handle(runeEvent('d')); req.kind != requestNone || m.mode != modeList {
+		t.Errorf("expected main worktree removal to be refused, got %+v", req)
+	}
+
+	m.handle(runeEvent('j'))
+	m.handle(runeEvent('d'))
+	if req := m.handle(runeEvent('n')); req.kind != requestNone {
+		t.Errorf("expected removal to be cancelled, got %+v", req)
+	}
+
+	m.handle(runeEvent('d'))
+	req := m.handle(runeEvent('y'))
+	if req.kind != requestClear || req.row.Worktree.Branch != "feature/a" {
+		t.Errorf("unexpected request: %+v", req)
+	}
+}
+
+func TestDiffScroll(t *testing.T) {
+	m := testModel()
+	m.height = 10
+
+	m.setDiff("")
+	if m.mode != modeList {
+		t.Fatal("expected empty diff not to open the diff view")
+	}
+
+	m.setDiff(strings.Repeat("+line\n", 20))
+	m.handle(runeEvent('G'))
+	if m.diffOffset != 12 {
+		t.Errorf("expected offset 12 at the end, got %d", m.diffOffset)
+	}
+	m.handle(runeEvent('b'))
+	if m.diffOffset != 4 {
+		t.Errorf("expected offset 4 after page up, got %d", m.diffOffset)
+	}
+	m.handle(runeEvent('q'))
+	if m.mode != modeList {
+		t.Errorf("expected to return to the list, got mode %d", m.mode)
+	}
+}
+
+func TestView(t *testing.T) {
+	m := testModel()
+
+	lines := m.view(120, 12)
+	if len(lines) != 12 {
+		t.Fatalf("expected 12 lines, got %d", len(lines))
+	}
+
+	text := strings.Join(lines, "\n")
+	for _, want := range []string{"feature/b [locked]", "↑2 ↓1", "claude", "/repo/wtree/feature-a"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected view to contain %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestFormatAgents(t *testing.T) {
+	if got := formatAgents(Row{}); got != "-" {
+		t.Errorf("expected '-' without agents, got %q", got)
+	}
+	row := Row{Agents: []agent.Process{{Name: "claude"}, {Name: "aider"}}}
+	if got := formatAgents(row); got != "claude,aider" {
+		t.Errorf("expected 'claude,aider', got %q", got)
+	}
+	if got := formatAgents(Row{AgentsUnsupported: true}); got != i18n.Text(i18n.MsgUIAgentsUnsupported) {
+		t.Errorf("expected unsupported marker, got %q", got)
+	}
+}
@@ -0,0 +1,280 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ongasatoshi/scion/internal/agent"
+	"github.com/ongasatoshi/scion/internal/git"
+	"github.com/ongasatoshi/scion/internal/i18n"
+	"github.com/ongasatoshi/scion/internal/keys"
+	"golang.org/x/term"
+)
+
+// defaultInterval は状態を更新する間隔のデフォルト値
+const defaultInterval = 2 * time.Second
+
+// pollInterval はキー入力を待つ間隔
+// この間隔ごとに状態の更新結果を確認する
+const pollInterval = 100 * time.Millisecond
+
+// lockReason は worktree をロックする際に記録する理由
+const lockReason = "locked by scion ui"
+
+// ErrNotTerminal は端末でない場合に返される
+var ErrNotTerminal = errors.New("tui: not a terminal")
+
+// Options はダッシュボードの動作を指定する
+type Options struct {
+	// Title は画面の先頭に表示する文字列
+	Title string
+	// Agents は実行中のエージェントとして検出するプロセス名
+	Agents []string
+	// Interval は状態を更新する間隔（0 の場合は2秒）
+	Interval time.Duration
+
+	// 以下の操作は通常の画面に戻してから実行される
+	// Create は新しい worktree を作成する
+	Create func(branch string) error
+	// Clear は worktree を削除する
+	Clear func(wt git.WorktreeInfo) error
+	// Agent は worktree でエージェントを起動する
+	Agent func(wt git.WorktreeInfo) error
+	// Editor は worktree をエディタで開く
+	Editor func(wt git.WorktreeInfo) error
+}
+
+// Available はダッシュボードを表示できる端末かどうかを返す
+func Available() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Run はダッシュボードを表示し、ユーザーが終了するまで待つ
+func Run(opts Options) error {
+	if !Available() {
+		return ErrNotTerminal
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultInterval
+	}
+
+	// キー入力をタイムアウト付きで読み込むため、端末を別途開く
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	s := &screen{tty: tty, fd: int(tty.Fd())}
+	if err := s.enter(); err != nil {
+		return err
+	}
+	defer s.leave()
+
+	m := &model{title: opts.Title}
+	rows, err := collect(opts.Agents)
+	if err != nil {
+		return err
+	}
+	m.setRows(rows)
+
+	updates := make(chan []Row, 1)
+	refreshing := false
+	lastRefresh := time.Now()
+	refresh := func() {
+		if refreshing {
+			return
+		}
+		refreshing = true
+		go func() {
+			// 更新に失敗した場合は前回の状態を表示し続ける
+			rows, err := collect(opts.Agents)
+			if err != nil {
+				rows = nil
+			}
+			updates <- rows
+		}()
+	}
+
+	buf := make([]byte, 64)
+	for {
+		select {
+		case rows := <-updates:
+			refreshing = false
+			lastRefresh = time.Now()
+			if rows != nil {
+				m.setRows(rows)
+			}
+		default:
+		}
+		if m.mode != modeDiff && time.Since(lastRefresh) >= opts.Interval {
+			refresh()
+		}
+
+		s.draw(m)
+
+		tty.SetReadDeadline(time.Now().Add(pollInterval))
+		n, err := tty.Read(buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, ev := range keys.Parse(buf[:n]) {
+			req := m.handle(ev)
+			switch req.kind {
+			case requestQuit:
+				return nil
+			case requestRefresh:
+				refresh()
+			case requestDiff:
+				diff, err := git.Diff(req.row.Worktree.Path)
+				// 未追跡のファイルだけが変更されている場合は状態を表示する
+				if err == nil && strings.TrimSpace(diff) == "" && req.row.Changes > 0 {
+					diff, err = git.Status(req.row.Worktree.Path)
+				}
+				if err != nil {
+					m.message = err.Error()
+					continue
+				}
+				m.setDiff(diff)
+			case requestLock:
+				m.message = toggleLock(req.row.Worktree)
+				refresh()
+			case requestCreate:
+				m.message = s.suspend(func() error { return opts.Create(req.branch) }, true)
+				refresh()
+			case requestClear:
+				m.message = s.suspend(func() error { return opts.Clear(req.row.Worktree) }, true)
+				refresh()
+			case requestAgent:
+				m.message = s.suspend(func() error { return opts.Agent(req.row.Worktree) }, false)
+				refresh()
+			case requestEditor:
+				m.message = s.suspend(func() error { return opts.Editor(req.row.Worktree) }, false)
+			}
+		}
+	}
+}
+
+// collect は worktree の一覧と状態を取得する
+func collect(agentNames []string) ([]Row, error) {
+	worktrees, err := git.ListWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	// エージェントの検出に失敗しても一覧は表示する
+	// 検出する方法がない環境では、エージェントの列に未対応であることを表示する
+	processes, err := agent.Scan(agentNames)
+	unsupported := errors.Is(err, agent.ErrUnsupported)
+	var dirs []string
+	for _, wt := range worktrees {
+		dirs = append(dirs, wt.Path)
+	}
+	groups := agent.Group(processes, dirs)
+
+	rows := make([]Row, 0, len(worktrees))
+	for i, wt := range worktrees {
+		if wt.IsBare {
+			continue
+		}
+
+		row := Row{Worktree: wt, Main: i == 0, Agents: groups[wt.Path], AgentsUnsupported: unsupported}
+		if changes, err := git.ChangedFiles(wt.Path); err == nil {
+			row.Changes = changes
+		} else {
+			row.Changes = -1
+		}
+		row.Ahead, row.Behind, row.Upstream = git.AheadBehind(wt.Path)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// toggleLock は worktree のロックを切り替え、結果のメッセージを返す
+func toggleLock(wt git.WorktreeInfo) string {
+	if wt.Locked {
+		if err := git.UnlockWorktree(wt.Path); err != nil {
+			return strings.TrimSpace(err.Error())
+		}
+		return i18n.T(i18n.MsgUIUnlocked, wt.Branch)
+	}
+	if err := git.LockWorktree(wt.Path, lockReason); err != nil {
+		return strings.TrimSpace(err.Error())
+	}
+	return i18n.T(i18n.MsgUILocked, wt.Branch)
+}
+
+// screen はダッシュボードを描画する端末
+type screen struct {
+	tty   *os.File
+	fd    int
+	state *term.State
+}
+
+// enter は端末を raw モードにして代替画面に切り替える
+func (s *screen) enter() error {
+	state, err := term.MakeRaw(s.fd)
+	if err != nil {
+		return err
+	}
+	s.state = state
+	io.WriteString(s.tty, "\033[?1049h\033[?25l")
+	return nil
+}
+
+// leave は端末を元の状態に戻す
+func (s *screen) leave() {
+	io.WriteString(s.tty, "\033[?25h\033[?1049l")
+	if s.state != nil {
+		term.Restore(s.fd, s.state)
+		s.state = nil
+	}
+}
+
+// draw は画面全体を描画し直す
+func (s *screen) draw(m *model) {
+	width, height, err := term.GetSize(s.fd)
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = 80, 24
+	}
+	// 行末ちょうどまで描画すると端末によっては折り返されるため、1桁空けておく
+	lines := m.view(width-1, height)
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	io.WriteString(s.tty, "\033[H"+strings.Join(lines, "\033[K\r\n")+"\033[K\033[J")
+}
+
+// suspend は通常の画面に戻して fn を実行し、結果のメッセージを返す
+// wait が true の場合、またはエラーが発生した場合は出力を確認できるようキー入力を待つ
+func (s *screen) suspend(fn func() error, wait bool) string {
+	s.leave()
+	err := fn()
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+		fmt.Fprintf(s.tty, "\n%s\n", message)
+		wait = true
+	}
+
+	// 再び raw モードにしてから1キー待つ
+	if state, rawErr := term.MakeRaw(s.fd); rawErr == nil {
+		s.state = state
+		if wait {
+			fmt.Fprintf(s.tty, "\r\n%s", i18n.Text(i18n.MsgUIPressAnyKey))
+			s.tty.SetReadDeadline(time.Time{})
+			s.tty.Read(make([]byte, 64))
+		}
+	}
+	io.WriteString(s.tty, "\033[?1049h\033[?25l")
+	return message
+}
@@ -0,0 +1,41 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// formatEscapes はシェルから入力しやすいよう --format で受け付けるエスケープシーケンス
+var formatEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`)
+
+// formatFuncs はテンプレート内で使用できる関数
+var formatFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+}
+
+// ParseFormat は --format で指定されたGoテンプレートを解析する
+// 文字列としての \t と \n はタブと改行に変換される
+func ParseFormat(format string) (*template.Template, error) {
+	return template.New("format").Funcs(formatFuncs).Parse(formatEscapes.Replace(format))
+}
+
+// RenderFormat は items の各要素にテンプレートを適用し、1要素につき1行を書き込む
+func RenderFormat[T any](w io.Writer, tmpl *template.Template, items []T) error {
+	for _, item := range items {
+		if err := tmpl.Execute(w, item); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
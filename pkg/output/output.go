@@ -1,12 +1,28 @@
 package output
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 )
 
-// ColorEnabled はカラー出力が有効かどうか
-var ColorEnabled = true
+// Level は出力の詳細度
+type Level int
+
+const (
+	// LevelQuiet は警告とエラーのみを出力する
+	LevelQuiet Level = iota
+	// LevelNormal は通常の出力
+	LevelNormal
+	// LevelVerbose は詳細な情報も出力する
+	LevelVerbose
+	// LevelDebug は実行したgitコマンドなどのデバッグ情報も出力する
+	LevelDebug
+)
+
+// NoColorEnv はカラー出力を無効にする環境変数 (https://no-color.org/)
+const NoColorEnv = "NO_COLOR"
 
 // ANSI カラーコード
 const (
@@ -16,54 +32,205 @@ const (
 	colorYellow = "\033[33m"
 	colorBlue   = "\033[34m"
 	colorCyan   = "\033[36m"
+	colorGray   = "\033[90m"
 )
 
+// Printer はメッセージの出力先と出力形式を保持する
+type Printer struct {
+	// Out は成功・情報メッセージと通常の出力の出力先（nil の場合は標準出力）
+	Out io.Writer
+	// Err は警告・エラー・詳細・デバッグメッセージの出力先（nil の場合は標準エラー出力）
+	Err io.Writer
+	// Color は Out へのカラー出力が有効かどうか
+	Color bool
+	// ErrColor は Err へのカラー出力が有効かどうか
+	// 標準出力と標準エラー出力の一方だけがリダイレクトされることがあるため、出力先ごとに指定する
+	ErrColor bool
+	// Level は出力の詳細度
+	Level Level
+}
+
+// NewPrinter は指定した出力先に書き込む Printer を作成する
+// カラー出力は無効、詳細度は LevelNormal で作成される
+func NewPrinter(out, err io.Writer) *Printer {
+	return &Printer{
+		Out:   out,
+		Err:   err,
+		Level: LevelNormal,
+	}
+}
+
+// defaultPrinter はパッケージレベルの関数が使用する Printer
+var defaultPrinter = &Printer{Color: true, ErrColor: true, Level: LevelNormal}
+
+// Default はパッケージレベルの関数が使用する Printer を返す
+func Default() *Printer {
+	return defaultPrinter
+}
+
+// SetDefault はパッケージレベルの関数が使用する Printer を設定する
+func SetDefault(p *Printer) {
+	defaultPrinter = p
+}
+
+type printerKey struct{}
+
+// WithPrinter は Printer を保持したコンテキストを返す
+func WithPrinter(ctx context.Context, p *Printer) context.Context {
+	return context.WithValue(ctx, printerKey{}, p)
+}
+
+// FromContext はコンテキストに保持された Printer を返す
+// 保持されていない場合は Default() を返す
+func FromContext(ctx context.Context) *Printer {
+	if ctx != nil {
+		if p, ok := ctx.Value(printerKey{}).(*Printer); ok {
+			return p
+		}
+	}
+	return Default()
+}
+
+// Stdout は通常の出力先を返す
+func (p *Printer) Stdout() io.Writer {
+	if p.Out == nil {
+		return os.Stdout
+	}
+	return p.Out
+}
+
+// Stderr は警告・エラーの出力先を返す
+func (p *Printer) Stderr() io.Writer {
+	if p.Err == nil {
+		return os.Stderr
+	}
+	return p.Err
+}
+
 // Success は成功メッセージを出力する
-func Success(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	if ColorEnabled {
-		fmt.Printf("%s✓ %s%s\n", colorGreen, msg, colorReset)
-	} else {
-		fmt.Printf("✓ %s\n", msg)
+func (p *Printer) Success(format string, args ...interface{}) {
+	if p.Level < LevelNormal {
+		return
 	}
+	p.printMessage(p.Stdout(), p.Color, colorGreen, "✓ ", fmt.Sprintf(format, args...))
 }
 
 // Error はエラーメッセージを出力する
-func Error(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	if ColorEnabled {
-		fmt.Fprintf(os.Stderr, "%s✗ %s%s\n", colorRed, msg, colorReset)
-	} else {
-		fmt.Fprintf(os.Stderr, "✗ %s\n", msg)
-	}
+func (p *Printer) Error(format string, args ...interface{}) {
+	p.printMessage(p.Stderr(), p.ErrColor, colorRed, "✗ ", fmt.Sprintf(format, args...))
 }
 
 // Warning は警告メッセージを出力する
-func Warning(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	if ColorEnabled {
-		fmt.Printf("%s⚠ %s%s\n", colorYellow, msg, colorReset)
-	} else {
-		fmt.Printf("⚠ %s\n", msg)
-	}
+func (p *Printer) Warning(format string, args ...interface{}) {
+	p.printMessage(p.Stderr(), p.ErrColor, colorYellow, "⚠ ", fmt.Sprintf(format, args...))
 }
 
 // Info は情報メッセージを出力する
-func Info(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	if ColorEnabled {
-		fmt.Printf("%s→ %s%s\n", colorCyan, msg, colorReset)
+func (p *Printer) Info(format string, args ...interface{}) {
+	if p.Level < LevelNormal {
+		return
+	}
+	p.printMessage(p.Stdout(), p.Color, colorCyan, "→ ", fmt.Sprintf(format, args...))
+}
+
+// Verbose は詳細モードでのみ情報メッセージを出力する
+func (p *Printer) Verbose(format string, args ...interface{}) {
+	if p.Level < LevelVerbose {
+		return
+	}
+	p.printMessage(p.Stderr(), p.ErrColor, colorBlue, "· ", fmt.Sprintf(format, args...))
+}
+
+// Debug はデバッグモードでのみメッセージを出力する
+func (p *Printer) Debug(format string, args ...interface{}) {
+	if p.Level < LevelDebug {
+		return
+	}
+	p.printMessage(p.Stderr(), p.ErrColor, colorGray, "[debug] ", fmt.Sprintf(format, args...))
+}
+
+// Print は通常のメッセージを出力する
+func (p *Printer) Print(format string, args ...interface{}) {
+	fmt.Fprintf(p.Stdout(), format+"\n", args...)
+}
+
+// printMessage は記号付きのメッセージを、colored が true の場合は色付きで出力する
+func (p *Printer) printMessage(w io.Writer, colored bool, color, symbol, msg string) {
+	if colored {
+		fmt.Fprintf(w, "%s%s%s%s\n", color, symbol, msg, colorReset)
 	} else {
-		fmt.Printf("→ %s\n", msg)
+		fmt.Fprintf(w, "%s%s\n", symbol, msg)
 	}
 }
 
+// Success は成功メッセージを標準出力に出力する
+func Success(format string, args ...interface{}) {
+	Default().Success(format, args...)
+}
+
+// Error はエラーメッセージを標準エラー出力に出力する
+func Error(format string, args ...interface{}) {
+	Default().Error(format, args...)
+}
+
+// Warning は警告メッセージを標準エラー出力に出力する
+func Warning(format string, args ...interface{}) {
+	Default().Warning(format, args...)
+}
+
+// Info は情報メッセージを標準出力に出力する
+func Info(format string, args ...interface{}) {
+	Default().Info(format, args...)
+}
+
+// Verbose は詳細モードでのみ情報メッセージを標準エラー出力に出力する
+func Verbose(format string, args ...interface{}) {
+	Default().Verbose(format, args...)
+}
+
+// Debug はデバッグモードでのみメッセージを標準エラー出力に出力する
+func Debug(format string, args ...interface{}) {
+	Default().Debug(format, args...)
+}
+
 // Print は通常のメッセージを出力する
 func Print(format string, args ...interface{}) {
-	fmt.Printf(format+"\n", args...)
+	Default().Print(format, args...)
 }
 
 // SetColorEnabled はカラー出力の有効/無効を設定する
 func SetColorEnabled(enabled bool) {
-	ColorEnabled = enabled
+	Default().Color = enabled
+	Default().ErrColor = enabled
+}
+
+// SetLevel は出力の詳細度を設定する
+func SetLevel(level Level) {
+	Default().Level = level
+}
+
+// ColorSupported は端末がカラー出力に対応しているかどうかを返す
+// 環境変数 NO_COLOR が設定されている場合、TERM=dumb の場合、w が端末でない場合は false を返す
+func ColorSupported(w io.Writer) bool {
+	return ColorAllowed() && IsTerminal(w)
+}
+
+// ColorAllowed は環境変数がカラー出力を禁止していないかどうかを返す
+// 環境変数 NO_COLOR が設定されている場合と TERM=dumb の場合は false を返す
+func ColorAllowed() bool {
+	return os.Getenv(NoColorEnv) == "" && os.Getenv("TERM") != "dumb"
+}
+
+// IsTerminal は出力先が端末に接続されているかどうかを返す
+// *os.File 以外の出力先は常に false を返す
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
 }
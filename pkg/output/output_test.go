@@ -2,177 +2,254 @@ package output
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"strings"
 	"testing"
 )
 
+// newTestPrinter はバッファに書き込む Printer を作成する
+func newTestPrinter() (*Printer, *bytes.Buffer, *bytes.Buffer) {
+	var out, errOut bytes.Buffer
+	return NewPrinter(&out, &errOut), &out, &errOut
+}
+
 func TestSetColorEnabled(t *testing.T) {
 	// 初期状態を保存
-	original := ColorEnabled
+	original := Default().Color
+
+	originalErr := Default().ErrColor
 
 	SetColorEnabled(false)
-	if ColorEnabled {
-		t.Error("expected ColorEnabled to be false")
+	if Default().Color || Default().ErrColor {
+		t.Error("expected color to be disabled")
 	}
 
 	SetColorEnabled(true)
-	if !ColorEnabled {
-		t.Error("expected ColorEnabled to be true")
+	if !Default().Color || !Default().ErrColor {
+		t.Error("expected color to be enabled")
 	}
 
 	// 元の状態に戻す
-	ColorEnabled = original
+	Default().Color = original
+	Default().ErrColor = originalErr
 }
 
 func TestSuccessOutput(t *testing.T) {
-	// カラーを無効にしてテスト
-	original := ColorEnabled
-	ColorEnabled = false
-	defer func() { ColorEnabled = original }()
-
-	// 標準出力をキャプチャ
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	p, out, _ := newTestPrinter()
 
-	Success("test message")
-
-	w.Close()
-	os.Stdout = oldStdout
-
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	output := buf.String()
+	p.Success("test message")
 
-	if !strings.Contains(output, "test message") {
-		t.Errorf("expected output to contain 'test message', got '%s'", output)
+	if !strings.Contains(out.String(), "test message") {
+		t.Errorf("expected output to contain 'test message', got '%s'", out.String())
 	}
 
-	if !strings.Contains(output, "✓") {
-		t.Errorf("expected output to contain '✓', got '%s'", output)
+	if !strings.Contains(out.String(), "✓") {
+		t.Errorf("expected output to contain '✓', got '%s'", out.String())
 	}
 }
 
 func TestInfoOutput(t *testing.T) {
-	original := ColorEnabled
-	ColorEnabled = false
-	defer func() { ColorEnabled = original }()
+	p, out, _ := newTestPrinter()
 
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	p.Info("info message")
 
-	Info("info message")
-
-	w.Close()
-	os.Stdout = oldStdout
-
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	output := buf.String()
-
-	if !strings.Contains(output, "info message") {
-		t.Errorf("expected output to contain 'info message', got '%s'", output)
+	if !strings.Contains(out.String(), "info message") {
+		t.Errorf("expected output to contain 'info message', got '%s'", out.String())
 	}
 
-	if !strings.Contains(output, "→") {
-		t.Errorf("expected output to contain '→', got '%s'", output)
+	if !strings.Contains(out.String(), "→") {
+		t.Errorf("expected output to contain '→', got '%s'", out.String())
 	}
 }
 
 func TestWarningOutput(t *testing.T) {
-	original := ColorEnabled
-	ColorEnabled = false
-	defer func() { ColorEnabled = original }()
+	p, out, errOut := newTestPrinter()
 
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	p.Warning("warning message")
 
-	Warning("warning message")
+	if !strings.Contains(errOut.String(), "warning message") {
+		t.Errorf("expected output to contain 'warning message', got '%s'", errOut.String())
+	}
 
-	w.Close()
-	os.Stdout = oldStdout
+	if !strings.Contains(errOut.String(), "⚠") {
+		t.Errorf("expected output to contain '⚠', got '%s'", errOut.String())
+	}
 
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	output := buf.String()
+	if out.Len() != 0 {
+		t.Errorf("expected nothing on stdout, got '%s'", out.String())
+	}
+}
+
+func TestErrorOutput(t *testing.T) {
+	p, _, errOut := newTestPrinter()
 
-	if !strings.Contains(output, "warning message") {
-		t.Errorf("expected output to contain 'warning message', got '%s'", output)
+	p.Error("error message")
+
+	if !strings.Contains(errOut.String(), "error message") {
+		t.Errorf("expected output to contain 'error message', got '%s'", errOut.String())
 	}
 
-	if !strings.Contains(output, "⚠") {
-		t.Errorf("expected output to contain '⚠', got '%s'", output)
+	if !strings.Contains(errOut.String(), "✗") {
+		t.Errorf("expected output to contain '✗', got '%s'", errOut.String())
 	}
 }
 
-func TestErrorOutput(t *testing.T) {
-	original := ColorEnabled
-	ColorEnabled = false
-	defer func() { ColorEnabled = original }()
+func TestPrintOutput(t *testing.T) {
+	p, out, _ := newTestPrinter()
 
-	oldStderr := os.Stderr
-	r, w, _ := os.Pipe()
-	os.Stderr = w
+	p.Print("plain message")
 
-	Error("error message")
+	if !strings.Contains(out.String(), "plain message") {
+		t.Errorf("expected output to contain 'plain message', got '%s'", out.String())
+	}
+}
 
-	w.Close()
-	os.Stderr = oldStderr
+func TestFormatArgs(t *testing.T) {
+	p, out, _ := newTestPrinter()
 
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	output := buf.String()
+	p.Success("message with %s and %d", "string", 42)
 
-	if !strings.Contains(output, "error message") {
-		t.Errorf("expected output to contain 'error message', got '%s'", output)
+	if !strings.Contains(out.String(), "message with string and 42") {
+		t.Errorf("expected formatted output, got '%s'", out.String())
 	}
+}
+
+func TestColorOutput(t *testing.T) {
+	p, out, _ := newTestPrinter()
 
-	if !strings.Contains(output, "✗") {
-		t.Errorf("expected output to contain '✗', got '%s'", output)
+	p.Color = true
+	p.Success("colored")
+	if !strings.Contains(out.String(), colorGreen) {
+		t.Errorf("expected colored output, got %q", out.String())
+	}
+
+	out.Reset()
+	p.Color = false
+	p.Success("plain")
+	if strings.Contains(out.String(), "\033[") {
+		t.Errorf("expected output without escape sequences, got %q", out.String())
 	}
 }
 
-func TestPrintOutput(t *testing.T) {
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+func TestColorPerWriter(t *testing.T) {
+	p, out, errOut := newTestPrinter()
 
-	Print("plain message")
+	p.Color = true
+	p.ErrColor = false
+	p.Info("info")
+	p.Warning("warning")
+	if !strings.Contains(out.String(), colorCyan) {
+		t.Errorf("expected colored stdout, got %q", out.String())
+	}
+	if strings.Contains(errOut.String(), "\033[") {
+		t.Errorf("expected stderr without escape sequences, got %q", errOut.String())
+	}
 
-	w.Close()
-	os.Stdout = oldStdout
+	out.Reset()
+	errOut.Reset()
+	p.Color = false
+	p.ErrColor = true
+	p.Info("info")
+	p.Warning("warning")
+	if strings.Contains(out.String(), "\033[") {
+		t.Errorf("expected stdout without escape sequences, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), colorYellow) {
+		t.Errorf("expected colored stderr, got %q", errOut.String())
+	}
+}
 
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	output := buf.String()
+func TestLevels(t *testing.T) {
+	tests := []struct {
+		level       Level
+		wantInfo    bool
+		wantVerbose bool
+		wantDebug   bool
+	}{
+		{LevelQuiet, false, false, false},
+		{LevelNormal, true, false, false},
+		{LevelVerbose, true, true, false},
+		{LevelDebug, true, true, true},
+	}
 
-	if !strings.Contains(output, "plain message") {
-		t.Errorf("expected output to contain 'plain message', got '%s'", output)
+	for _, tt := range tests {
+		p, out, errOut := newTestPrinter()
+		p.Level = tt.level
+
+		p.Info("info")
+		p.Verbose("verbose")
+		p.Debug("debug")
+		p.Warning("warning")
+
+		if got := strings.Contains(out.String(), "info"); got != tt.wantInfo {
+			t.Errorf("level %d: info printed = %v, want %v", tt.level, got, tt.wantInfo)
+		}
+		if got := strings.Contains(errOut.String(), "verbose"); got != tt.wantVerbose {
+			t.Errorf("level %d: verbose printed = %v, want %v", tt.level, got, tt.wantVerbose)
+		}
+		if got := strings.Contains(errOut.String(), "debug"); got != tt.wantDebug {
+			t.Errorf("level %d: debug printed = %v, want %v", tt.level, got, tt.wantDebug)
+		}
+		if !strings.Contains(errOut.String(), "warning") {
+			t.Errorf("level %d: expected warning to be printed", tt.level)
+		}
 	}
 }
 
-func TestFormatArgs(t *testing.T) {
-	original := ColorEnabled
-	ColorEnabled = false
-	defer func() { ColorEnabled = original }()
+func TestPackageLevelUsesDefault(t *testing.T) {
+	original := Default()
+	defer SetDefault(original)
+
+	p, out, _ := newTestPrinter()
+	SetDefault(p)
+
+	Info("via default")
 
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	if !strings.Contains(out.String(), "via default") {
+		t.Errorf("expected package-level output to use the default printer, got '%s'", out.String())
+	}
+}
 
-	Success("message with %s and %d", "string", 42)
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != Default() {
+		t.Error("expected default printer when context has none")
+	}
 
-	w.Close()
-	os.Stdout = oldStdout
+	p, _, _ := newTestPrinter()
+	ctx := WithPrinter(context.Background(), p)
+	if FromContext(ctx) != p {
+		t.Error("expected printer stored in context")
+	}
+}
 
+func TestColorSupported(t *testing.T) {
 	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	output := buf.String()
+	if ColorSupported(&buf) {
+		t.Error("expected no color support for a buffer")
+	}
+
+	t.Setenv(NoColorEnv, "1")
+	if ColorSupported(os.Stdout) {
+		t.Error("expected no color support when NO_COLOR is set")
+	}
+}
+
+func TestColorAllowed(t *testing.T) {
+	t.Setenv(NoColorEnv, "")
+	t.Setenv("TERM", "xterm-256color")
+	if !ColorAllowed() {
+		t.Error("expected color to be allowed")
+	}
+
+	t.Setenv("TERM", "dumb")
+	if ColorAllowed() {
+		t.Error("expected color to be disallowed for TERM=dumb")
+	}
 
-	if !strings.Contains(output, "message with string and 42") {
-		t.Errorf("expected formatted output, got '%s'", output)
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv(NoColorEnv, "1")
+	if ColorAllowed() {
+		t.Error("expected color to be disallowed when NO_COLOR is set")
 	}
 }
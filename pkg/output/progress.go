@@ -0,0 +1,391 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// spinnerFrames はスピナーの表示に使う文字
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval はスピナーを更新する間隔
+const spinnerInterval = 100 * time.Millisecond
+
+// plainInterval は端末以外に進捗を出力する間隔
+var plainInterval = 5 * time.Second
+
+// barWidth は進捗バーの幅
+const barWidth = 20
+
+// gitProgressPattern は git の --progress の出力から段階と件数・割合を読み取る
+// 例: "Receiving objects:  45% (450/1000), 1.20 MiB | 2.00 MiB/s", "remote: Enumerating objects: 12, done."
+var gitProgressPattern = regexp.MustCompile(`^(?:remote: )?([A-Z][A-Za-z ]*):\s+(\d+)(%?)`)
+
+// Progress は時間のかかる処理の進捗を表示する
+// io.Writer として git の --progress の出力を受け取り、段階と割合を読み取って表示する
+// 端末ではスピナーと進捗バーを1行で更新し、端末以外では一定間隔ごとに進捗を1行ずつ出力する
+type Progress struct {
+	w       io.Writer
+	color   bool
+	tty     bool
+	enabled bool
+	label   string
+	start   time.Time
+
+	mu        sync.Mutex
+	phase     string
+	percent   int
+	partial   []byte
+	lastPrint time.Time
+	frame     int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// StartProgress は label を表示して進捗の表示を開始する
+// 端末以外では label を情報メッセージとして出力する。quiet の場合は何も表示しない
+func (p *Printer) StartProgress(label string) *Progress {
+	pr := &Progress{
+		w:       p.Stderr(),
+		color:   p.ErrColor,
+		tty:     IsTerminal(p.Stderr()),
+		enabled: p.Level >= LevelNormal,
+		label:   label,
+		start:   time.Now(),
+		percent: -1,
+	}
+	pr.lastPrint = pr.start
+	if !pr.enabled {
+		return pr
+	}
+	if !pr.tty {
+		p.printMessage(p.Stdout(), p.Color, colorCyan, "→ ", label)
+		return pr
+	}
+
+	pr.stop = make(chan struct{})
+	pr.done = make(chan struct{})
+	go pr.animate()
+	return pr
+}
+
+// animate は Stop が呼ばれるまでスピナーを更新する
+func (pr *Progress) animate() {
+	defer close(pr.done)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	pr.draw()
+	for {
+		select {
+		case <-pr.stop:
+			return
+		case <-ticker.C:
+			pr.draw()
+		}
+	}
+}
+
+// draw は端末の現在の行に進捗を描画する
+func (pr *Progress) draw() {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	frame := spinnerFrames[pr.frame%len(spinnerFrames)]
+	pr.frame++
+	if pr.color {
+		frame = colorCyan + frame + colorReset
+	}
+
+	line := pr.line(time.Since(pr.start))
+	if width := TerminalWidth(pr.w); width > 2 {
+		line = Truncate(line, width-3)
+	}
+	fmt.Fprintf(pr.w, "\r%s %s\033[K", frame, line)
+}
+
+// line はスピナーに続けて表示する進捗の文字列を返す
+func (pr *Progress) line(elapsed time.Duration) string {
+	var b strings.Builder
+	b.WriteString(pr.label)
+	if pr.phase != "" {
+		b.WriteString("  ")
+		b.WriteString(pr.phase)
+	}
+	if pr.percent >= 0 {
+		filled := pr.percent * barWidth / 100
+		fmt.Fprintf(&b, " [%s%s] %3d%%", strings.Repeat("█", filled), strings.Repeat("░", barWidth-filled), pr.percent)
+	}
+	if elapsed >= time.Second {
+		fmt.Fprintf(&b, "  %ds", int(elapsed.Seconds()))
+	}
+	return b.String()
+}
+
+// Update は現在の段階と割合（不明な場合は -1）を設定する
+func (pr *Progress) Update(phase string, percent int) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.update(phase, percent)
+}
+
+// update はロックを取得した状態で進捗を更新し、端末以外では一定間隔ごとに出力する
+func (pr *Progress) update(phase string, percent int) {
+	if percent > 100 {
+		percent = 100
+	}
+	pr.phase = phase
+	pr.percent = percent
+
+	if !pr.enabled || pr.tty || time.Since(pr.lastPrint) < plainInterval {
+		return
+	}
+	pr.lastPrint = time.Now()
+	line := "  " + pr.phase
+	if pr.percent >= 0 {
+		line += fmt.Sprintf(" %d%%", pr.percent)
+	}
+	fmt.Fprintln(pr.w, line)
+}
+
+// Write は git の --progress の出力を受け取り、進捗を更新する
+// git は同じ行を \r で上書きするため、\r と \n の両方を行の区切りとして扱う
+func (pr *Progress) Write(b []byte) (int, error) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	pr.partial = append(pr.partial, b...)
+	for {
+		i := bytes.IndexAny(pr.partial, "\r\n")
+		if i < 0 {
+			break
+		}
+		line := string(pr.partial[:i])
+		pr.partial = pr.partial[i+1:]
+
+		m := gitProgressPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		percent := -1
+		if m[3] == "%" {
+			percent, _ = strconv.Atoi(m[2])
+		}
+		pr.update(m[1], percent)
+	}
+	return len(b), nil
+}
+
+// Stop は進捗の表示を終了し、端末では表示していた行を消去する
+func (pr *Progress) Stop() {
+	if pr.stop == nil {
+		return
+	}
+	close(pr.stop)
+	<-pr.done
+	pr.stop = nil
+	fmt.Fprint(pr.w, "\r\033[K")
+}
+
+// 項目の状態
+const (
+	itemPending = iota
+	itemRunning
+	itemDone
+	itemFailed
+)
+
+// progressItem は ProgressList の1項目
+type progressItem struct {
+	label  string
+	state  int
+	detail string
+}
+
+// ProgressList は複数の項目を順に処理する際の進捗を項目ごとに表示する
+// 端末では一覧全体をその場で更新し、端末以外では各項目の完了時に1行ずつ出力する
+type ProgressList struct {
+	p     *Printer
+	tty   bool
+	items []progressItem
+	// log は端末で一覧を表示している間に出力されたメッセージ
+	log bytes.Buffer
+
+	mu    sync.Mutex
+	drawn bool
+	frame int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// StartProgressList は項目の一覧を表示して進捗の表示を開始する
+// 一覧は処理の結果として標準出力に表示する
+func (p *Printer) StartProgressList(labels []string) *ProgressList {
+	l := &ProgressList{
+		p:   p,
+		tty: p.Level >= LevelNormal && IsTerminal(p.Stdout()),
+	}
+	for _, label := range labels {
+		l.items = append(l.items, progressItem{label: label})
+	}
+	if !l.tty {
+		return l
+	}
+
+	l.stop = make(chan struct{})
+	l.done = make(chan struct{})
+	go l.animate()
+	return l
+}
+
+// Printer は項目の処理中にメッセージを出力する Printer を返す
+// 端末で一覧を表示している間は一覧が崩れないよう、警告・エラーとフックなどの出力を保持し、Stop の後にまとめて表示する
+func (l *ProgressList) Printer() *Printer {
+	if !l.tty {
+		return l.p
+	}
+	w := &lockedWriter{mu: &l.mu, w: &l.log}
+	return &Printer{Out: w, Err: w, Color: l.p.ErrColor, ErrColor: l.p.ErrColor, Level: LevelQuiet}
+}
+
+// Start は i 番目の項目の処理を開始する
+func (l *ProgressList) Start(i int) {
+	l.set(i, itemRunning, "")
+}
+
+// Done は i 番目の項目の処理が成功したことを表示する
+func (l *ProgressList) Done(i int) {
+	l.set(i, itemDone, "")
+}
+
+// Fail は i 番目の項目の処理が失敗したことを表示する
+func (l *ProgressList) Fail(i int, err error) {
+	// gitのエラーメッセージは複数行になることがあるため、先頭行だけを表示する
+	detail, _, _ := strings.Cut(strings.TrimSpace(err.Error()), "\n")
+	l.set(i, itemFailed, detail)
+}
+
+// set は項目の状態を更新し、端末以外では完了した項目を出力する
+func (l *ProgressList) set(i, state int, detail string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.items[i].state = state
+	l.items[i].detail = detail
+	if l.tty {
+		l.draw()
+		return
+	}
+
+	switch state {
+	case itemDone:
+		if l.p.Level >= LevelNormal {
+			l.p.printMessage(l.p.Stdout(), l.p.Color, colorGreen, "✓ ", l.items[i].label)
+		}
+	case itemFailed:
+		l.p.printMessage(l.p.Stderr(), l.p.ErrColor, colorRed, "✗ ", l.items[i].label+": "+detail)
+	}
+}
+
+// animate は Stop が呼ばれるまで処理中の項目のスピナーを更新する
+func (l *ProgressList) animate() {
+	defer close(l.done)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	l.mu.Lock()
+	l.draw()
+	l.mu.Unlock()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			l.frame++
+			l.draw()
+			l.mu.Unlock()
+		}
+	}
+}
+
+// draw は一覧全体を描画し直す
+// 2回目以降は前回描画した行数だけカーソルを上に戻してから上書きする
+func (l *ProgressList) draw() {
+	w := l.p.Stdout()
+	if l.drawn {
+		fmt.Fprintf(w, "\033[%dA", len(l.items))
+	}
+	l.drawn = true
+
+	width := TerminalWidth(w)
+	for _, item := range l.items {
+		line := l.itemLine(item)
+		if width > 2 {
+			line = Truncate(line, width-3)
+		}
+		fmt.Fprintf(w, "\r%s %s\033[K\n", l.mark(item.state), line)
+	}
+}
+
+// itemLine は項目の表示を返す
+func (l *ProgressList) itemLine(item progressItem) string {
+	if item.detail == "" {
+		return item.label
+	}
+	return item.label + ": " + item.detail
+}
+
+// mark は項目の状態を表す記号を返す
+func (l *ProgressList) mark(state int) string {
+	var color, symbol string
+	switch state {
+	case itemRunning:
+		color, symbol = colorCyan, spinnerFrames[l.frame%len(spinnerFrames)]
+	case itemDone:
+		color, symbol = colorGreen, "✓"
+	case itemFailed:
+		color, symbol = colorRed, "✗"
+	default:
+		color, symbol = colorGray, "·"
+	}
+	if !l.p.Color {
+		return symbol
+	}
+	return color + symbol + colorReset
+}
+
+// Stop は進捗の表示を終了し、表示中に保持したメッセージを出力する
+func (l *ProgressList) Stop() {
+	if l.stop == nil {
+		return
+	}
+	close(l.stop)
+	<-l.done
+	l.stop = nil
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.draw()
+	l.log.WriteTo(l.p.Stderr())
+}
+
+// lockedWriter は ProgressList の描画と競合しないよう、ロックを取得して書き込む
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (w *lockedWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(b)
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressParsesGitOutput(t *testing.T) {
+	p, out, errOut := newTestPrinter()
+
+	original := plainInterval
+	plainInterval = 0
+	defer func() { plainInterval = original }()
+
+	pr := p.StartProgress("Fetching")
+	pr.Write([]byte("remote: Enumerating objects: 12, done.\nReceiving objects:  45% (450/1000), 1.2"))
+	pr.Write([]byte("0 MiB | 2.00 MiB/s\rReceiving objects: 100% (1000/1000), done.\nfatal: something\n"))
+	pr.Stop()
+
+	if out.String() != "→ Fetching\n" {
+		t.Errorf("unexpected label output: %q", out.String())
+	}
+	want := "  Enumerating objects\n  Receiving objects 45%\n  Receiving objects 100%\n"
+	if errOut.String() != want {
+		t.Errorf("unexpected progress output:\n%q\nwant:\n%q", errOut.String(), want)
+	}
+}
+
+func TestProgressLine(t *testing.T) {
+	pr := &Progress{label: "Creating", percent: -1}
+	if got := pr.line(0); got != "Creating" {
+		t.Errorf("unexpected line: %q", got)
+	}
+
+	pr.Update("Updating files", 50)
+	got := pr.line(3 * time.Second)
+	if !strings.HasPrefix(got, "Creating  Updating files [██████████░░░░░░░░░░]  50%") || !strings.HasSuffix(got, "3s") {
+		t.Errorf("unexpected line: %q", got)
+	}
+}
+
+func TestProgressQuiet(t *testing.T) {
+	p, out, errOut := newTestPrinter()
+	p.Level = LevelQuiet
+
+	pr := p.StartProgress("Fetching")
+	pr.Write([]byte("Receiving objects:  45% (450/1000)\r"))
+	pr.Stop()
+
+	if out.Len() != 0 || errOut.Len() != 0 {
+		t.Errorf("expected no output, got %q %q", out.String(), errOut.String())
+	}
+}
+
+func TestProgressListPlain(t *testing.T) {
+	p, out, errOut := newTestPrinter()
+
+	list := p.StartProgressList([]string{"feature/a", "feature/b"})
+	if list.Printer() != p {
+		t.Error("expected the original printer outside a terminal")
+	}
+	list.Start(0)
+	list.Done(0)
+	list.Start(1)
+	list.Fail(1, errors.New("has changes\nuse --force"))
+	list.Stop()
+
+	if out.String() != "✓ feature/a\n" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+	if errOut.String() != "✗ feature/b: has changes\n" {
+		t.Errorf("unexpected error output: %q", errOut.String())
+	}
+}
@@ -0,0 +1,228 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// columnGap は列の間の空白の幅
+const columnGap = 2
+
+// minTruncatedWidth は切り詰めた列に残す最小の幅
+const minTruncatedWidth = 8
+
+// ellipsis は切り詰めた箇所に表示する記号
+const ellipsis = "…"
+
+// Table は列を揃えて表示する表
+type Table struct {
+	headers  []string
+	rows     [][]string
+	truncate map[int]bool
+	// MaxWidth は表全体の最大幅（0 の場合は制限しない）
+	// 幅を超える場合は SetTruncatable で指定した列を先頭から切り詰める
+	MaxWidth int
+}
+
+// NewTable は見出しを指定して表を作成する
+func NewTable(headers ...string) *Table {
+	return &Table{
+		headers:  headers,
+		truncate: map[int]bool{},
+	}
+}
+
+// AddRow は行を追加する
+func (t *Table) AddRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// SetTruncatable は表が MaxWidth を超える場合に切り詰めてよい列を指定する
+// パスのように末尾が重要な値を想定し、先頭側を省略する
+func (t *Table) SetTruncatable(columns ...int) {
+	for _, col := range columns {
+		t.truncate[col] = true
+	}
+}
+
+// Render は表を書き込む
+func (t *Table) Render(w io.Writer) error {
+	widths := t.columnWidths()
+	t.shrink(widths)
+
+	lines := make([][]string, 0, len(t.rows)+1)
+	if len(t.headers) > 0 {
+		lines = append(lines, t.headers)
+	}
+	lines = append(lines, t.rows...)
+
+	for _, cells := range lines {
+		var b strings.Builder
+		for i := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = truncateLeft(cells[i], widths[i])
+			}
+			b.WriteString(cell)
+			// 最後の列は行末の空白を出力しない
+			if i < len(widths)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-DisplayWidth(cell)+columnGap))
+			}
+		}
+		if _, err := fmt.Fprintln(w, strings.TrimRight(b.String(), " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderTable は出力先の端末の幅に合わせて表を出力する
+func (p *Printer) RenderTable(t *Table) error {
+	if t.MaxWidth == 0 {
+		t.MaxWidth = TerminalWidth(p.Stdout())
+	}
+	return t.Render(p.Stdout())
+}
+
+// columnWidths は各列の表示幅を返す
+func (t *Table) columnWidths() []int {
+	n := len(t.headers)
+	for _, row := range t.rows {
+		if len(row) > n {
+			n = len(row)
+		}
+	}
+
+	widths := make([]int, n)
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			if w := DisplayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	measure(t.headers)
+	for _, row := range t.rows {
+		measure(row)
+	}
+	return widths
+}
+
+// shrink は表全体が MaxWidth に収まるよう、切り詰め可能な列の幅を縮める
+func (t *Table) shrink(widths []int) {
+	if t.MaxWidth <= 0 {
+		return
+	}
+
+	total := columnGap * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+
+	for total > t.MaxWidth {
+		// 最も幅の広い切り詰め可能な列から縮める
+		widest := -1
+		for i, w := range widths {
+			if t.truncate[i] && w > minTruncatedWidth && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// truncateLeft は表示幅が width を超える文字列の先頭を省略する
+func truncateLeft(s string, width int) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	kept := 0
+	i := len(runes)
+	for i > 0 {
+		w := runeWidth(runes[i-1])
+		if kept+w > width-DisplayWidth(ellipsis) {
+			break
+		}
+		kept += w
+		i--
+	}
+	return ellipsis + string(runes[i:])
+}
+
+// Truncate は表示幅が width を超える文字列の末尾を省略する
+func Truncate(s string, width int) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+
+	var b strings.Builder
+	kept := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if kept+w > width-DisplayWidth(ellipsis) {
+			break
+		}
+		b.WriteRune(r)
+		kept += w
+	}
+	return b.String() + ellipsis
+}
+
+// DisplayWidth は端末上での文字列の表示幅を返す
+// 全角文字は幅2として数える
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// runeWidth は1文字の表示幅を返す
+func runeWidth(r rune) int {
+	if r == utf8.RuneError || r < 0x1100 {
+		return 1
+	}
+	switch {
+	case r <= 0x115F, // ハングル字母
+		r >= 0x2E80 && r <= 0xA4CF, // CJK部首〜彝文字
+		r >= 0xAC00 && r <= 0xD7A3, // ハングル音節
+		r >= 0xF900 && r <= 0xFAFF, // CJK互換漢字
+		r >= 0xFE30 && r <= 0xFE4F, // CJK互換形
+		r >= 0xFF00 && r <= 0xFF60, // 全角形
+		r >= 0xFFE0 && r <= 0xFFE6,
+		r >= 0x1F300 && r <= 0x1F64F, // 絵文字
+		r >= 0x20000 && r <= 0x3FFFD: // CJK統合漢字拡張
+		return 2
+	}
+	return 1
+}
+
+// TerminalWidth は出力先の端末の幅を返す
+// 環境変数 COLUMNS が設定されている場合はその値を使用し、端末でない場合は 0 を返す
+func TerminalWidth(w io.Writer) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	f, ok := w.(*os.File)
+	if !ok || !IsTerminal(f) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -0,0 +1,127 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTableRender(t *testing.T) {
+	table := NewTable("BRANCH", "PATH")
+	table.AddRow("main", "/repo/app")
+	table.AddRow("feature/login", "/repo/wtree/feature-login")
+
+	var buf bytes.Buffer
+	if err := table.Render(&buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	want := "BRANCH         PATH\n" +
+		"main           /repo/app\n" +
+		"feature/login  /repo/wtree/feature-login\n"
+	if buf.String() != want {
+		t.Errorf("unexpected table:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestTableRenderWideCharacters(t *testing.T) {
+	table := NewTable("ブランチ", "PATH")
+	table.AddRow("main", "/repo")
+
+	var buf bytes.Buffer
+	if err := table.Render(&buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	// 全角4文字は幅8として揃える
+	if lines[1] != "main      /repo" {
+		t.Errorf("unexpected row: %q", lines[1])
+	}
+}
+
+func TestTableTruncate(t *testing.T) {
+	table := NewTable("BRANCH", "PATH")
+	table.AddRow("main", "/very/long/path/to/the/worktree")
+	table.SetTruncatable(1)
+	table.MaxWidth = 24
+
+	var buf bytes.Buffer
+	if err := table.Render(&buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		if DisplayWidth(line) > table.MaxWidth {
+			t.Errorf("line exceeds max width: %q", line)
+		}
+	}
+	if !strings.Contains(buf.String(), "…to/the/worktree") {
+		t.Errorf("expected path to keep its tail, got:\n%s", buf.String())
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"abc", 3},
+		{"パス", 4},
+		{"a…", 2},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.input); got != tt.want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"feature/login", 8, "feature…"},
+		{"ブランチ名", 5, "ブラ…"},
+	}
+
+	for _, tt := range tests {
+		if got := Truncate(tt.input, tt.width); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestRenderFormat(t *testing.T) {
+	type item struct {
+		Branch string
+		Path   string
+	}
+
+	tmpl, err := ParseFormat(`{{.Branch}}\t{{upper .Path}}`)
+	if err != nil {
+		t.Fatalf("ParseFormat failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	items := []item{{"main", "/a"}, {"dev", "/b"}}
+	if err := RenderFormat(&buf, tmpl, items); err != nil {
+		t.Fatalf("RenderFormat failed: %v", err)
+	}
+
+	want := "main\t/A\ndev\t/B\n"
+	if buf.String() != want {
+		t.Errorf("RenderFormat() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestParseFormatInvalid(t *testing.T) {
+	if _, err := ParseFormat("{{.Branch"); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}